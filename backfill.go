@@ -0,0 +1,323 @@
+package newsdata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"sync"
+	"time"
+)
+
+// backfillPagesBuffer is the number of pages buffered for each shard in ordered backfills.
+const backfillPagesBuffer = 10
+
+// ShardState is the state of a shard of a backfill, i.e. a date window fetched by a single request.
+type ShardState struct {
+	From     time.Time `json:"from"`             // Start of the window (inclusive)
+	To       time.Time `json:"to"`               // End of the window (exclusive)
+	Cursor   *Cursor   `json:"cursor,omitempty"` // Position after the last page retrieved, nil if no page was retrieved or the shard is done
+	Articles int       `json:"articles"`         // Number of articles retrieved
+	Done     bool      `json:"done"`             // Whether all the articles of the window were retrieved
+	Error    string    `json:"error,omitempty"`  // Error of the last attempt, if it failed
+}
+
+// BackfillState is the state of a backfill, from which it can be resumed with WithResumeBackfill.
+//
+// It can be serialized to JSON, e.g. to persist it between two runs of a batch job.
+type BackfillState struct {
+	Shards []ShardState `json:"shards"` // Shards of the backfill, in chronological order
+}
+
+// Done reports whether all the shards of the backfill are done.
+func (s BackfillState) Done() bool {
+	return !slices.ContainsFunc(s.Shards, func(shard ShardState) bool { return !shard.Done })
+}
+
+// clone returns a copy of the state, which is not modified by the backfill.
+func (s BackfillState) clone() BackfillState {
+	return BackfillState{Shards: slices.Clone(s.Shards)}
+}
+
+// BackfillProgress is reported after each page of a backfill, and after each shard failure.
+type BackfillProgress struct {
+	Shard int           // Index of the shard updated in State.Shards
+	State BackfillState // Snapshot of the state of the backfill
+}
+
+// BackfillError is the error returned when some shards of a backfill failed or were not completed.
+//
+// Its state can be used to resume the backfill with WithResumeBackfill.
+type BackfillError struct {
+	State BackfillState // State of the backfill when it stopped
+	Errs  []error       // Errors of the failed shards, and the context error if the backfill was cancelled
+}
+
+func (e *BackfillError) Error() string {
+	pending := 0
+	for _, shard := range e.State.Shards {
+		if !shard.Done {
+			pending++
+		}
+	}
+	return fmt.Sprintf("newsdata: Backfill - %d of %d shards not completed: %v", pending, len(e.State.Shards), errors.Join(e.Errs...))
+}
+
+// Unwrap returns the errors of the failed shards, so that errors.Is and errors.As can match them.
+func (e *BackfillError) Unwrap() []error {
+	return e.Errs
+}
+
+// backfillOptions are the options of a backfill.
+type backfillOptions struct {
+	params    []NewsArchiveParam
+	unordered bool
+	progress  func(BackfillProgress)
+	state     *BackfillState
+}
+
+// BackfillOption is a functional option for configuring NewsArchive.Backfill.
+type BackfillOption func(*backfillOptions)
+
+// WithBackfillParams sets the request parameters of the backfill, e.g. WithLanguages. They are sent with each shard,
+// along with its date range.
+func WithBackfillParams(params ...NewsArchiveParam) BackfillOption {
+	return func(o *backfillOptions) {
+		o.params = append(o.params, params...)
+	}
+}
+
+// WithUnordered yields the articles of a backfill as soon as they are retrieved, instead of in publication date order.
+//
+// It is faster, as shards do not wait for the previous ones to be consumed.
+func WithUnordered() BackfillOption {
+	return func(o *backfillOptions) {
+		o.unordered = true
+	}
+}
+
+// WithBackfillProgress calls progress after each page of a backfill, once its articles are yielded, and after each shard failure.
+//
+// progress is called from the goroutine iterating over the backfill.
+func WithBackfillProgress(progress func(BackfillProgress)) BackfillOption {
+	return func(o *backfillOptions) {
+		o.progress = progress
+	}
+}
+
+// WithResumeBackfill resumes a backfill from a state reported by WithBackfillProgress or returned in a BackfillError.
+//
+// The shards of the state replace the ones computed from the date range, and the shards already done are skipped.
+// Articles of the pages retrieved but not entirely yielded are retrieved again.
+func WithResumeBackfill(state BackfillState) BackfillOption {
+	return func(o *backfillOptions) {
+		o.state = &state
+	}
+}
+
+// shardPage is a page of a shard, or the error of the shard.
+type shardPage struct {
+	shard int
+	page  *Page
+	err   error
+}
+
+// Backfill returns an iterator over the archived news articles published between from (inclusive) and to (exclusive).
+//
+// The date range is split into windows of the shard duration, fetched in parallel by concurrency goroutines.
+// Requests still go through the rate limiter and the retry policy of the client. If shard is 0 or less,
+// the range is fetched as a single window.
+//
+// By default, articles are yielded in publication date order, most recent first. With WithUnordered,
+// they are yielded as soon as they are retrieved. Use WithBackfillParams to set the request parameters,
+// WithBackfillProgress to follow the progress of each shard, and WithResumeBackfill to resume an interrupted backfill.
+//
+// A failed shard does not stop the other ones: once all the shards are processed, a *BackfillError is yielded
+// as the last value if some shards are not done.
+//
+//	for article, err := range client.NewsArchive.Backfill(ctx, "bitcoin", from, to, 24*time.Hour, 4) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(article.Title)
+//	}
+func (s *NewsArchiveService) Backfill(ctx context.Context, query string, from, to time.Time, shard time.Duration, concurrency int, opts ...BackfillOption) iter.Seq2[*NewsArticle, error] {
+	return func(yield func(*NewsArticle, error) bool) {
+		var options backfillOptions
+		for _, opt := range opts {
+			opt(&options)
+		}
+
+		var state BackfillState
+		if options.state != nil {
+			state = options.state.clone()
+		} else {
+			if !from.Before(to) {
+				yield(nil, fmt.Errorf("newsdata: Backfill - from (%s) must be before to (%s)", from, to))
+				return
+			}
+			state = newBackfillState(from, to, shard)
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		var wg sync.WaitGroup
+		defer func() {
+			cancel()
+			wg.Wait()
+		}()
+
+		// Shards are fetched and consumed from the most recent to the oldest, as the articles of each shard.
+		var pending []int
+		for i := len(state.Shards) - 1; i >= 0; i-- {
+			if !state.Shards[i].Done {
+				pending = append(pending, i)
+			}
+		}
+		jobs := make(chan int, len(pending))
+		for _, i := range pending {
+			jobs <- i
+		}
+		close(jobs)
+
+		pages := make(map[int]chan shardPage, len(pending))
+		shared := make(chan shardPage, max(concurrency, 1))
+		for _, i := range pending {
+			if options.unordered {
+				pages[i] = shared
+			} else {
+				pages[i] = make(chan shardPage, backfillPagesBuffer)
+			}
+		}
+		for range max(concurrency, 1) {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range jobs {
+					s.fetchShard(ctx, query, options.params, i, state.Shards[i], pages[i], !options.unordered)
+				}
+			}()
+		}
+		if options.unordered {
+			go func() {
+				wg.Wait()
+				close(shared)
+			}()
+		}
+
+		var errs []error
+		// handle yields the articles of a page and updates the state. It returns false if the loop was exited.
+		handle := func(p shardPage) bool {
+			current := &state.Shards[p.shard]
+			if p.err != nil {
+				current.Error = p.err.Error()
+				errs = append(errs, fmt.Errorf("shard %s - %s: %w", current.From.Format(time.RFC3339), current.To.Format(time.RFC3339), p.err))
+			} else {
+				for _, article := range p.page.Articles {
+					if !yield(article, nil) {
+						return false
+					}
+				}
+				current.Articles += len(p.page.Articles)
+				current.Cursor = p.page.Cursor
+				current.Done = p.page.Cursor == nil
+				current.Error = ""
+			}
+			if options.progress != nil {
+				options.progress(BackfillProgress{Shard: p.shard, State: state.clone()})
+			}
+			return true
+		}
+
+		if options.unordered {
+			for p := range shared {
+				if !handle(p) {
+					return
+				}
+			}
+		} else {
+			for _, i := range pending {
+				for p := range pages[i] {
+					if !handle(p) {
+						return
+					}
+				}
+			}
+		}
+
+		if state.Done() {
+			return
+		}
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, fmt.Errorf("newsdata: Backfill - context done: %w", err))
+		}
+		yield(nil, &BackfillError{State: state, Errs: errs})
+	}
+}
+
+// fetchShard fetches the pages of a shard and sends them to out, closing it at the end if closeOut is true.
+func (s *NewsArchiveService) fetchShard(ctx context.Context, query string, params []NewsArchiveParam, index int, shard ShardState, out chan<- shardPage, closeOut bool) {
+	if closeOut {
+		defer close(out)
+	}
+	send := func(p shardPage) bool {
+		select {
+		case out <- p:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	params = append(slices.Clone(params), withDateWindow(shard.From, shard.To))
+	if shard.Cursor != nil {
+		params = append(params, WithResumeFrom(*shard.Cursor))
+	}
+	reqParams, err := newRequestParams(query, s.client.logger, s.client.strictParams, s.endpoint, params...)
+	if err != nil {
+		send(shardPage{shard: index, err: err})
+		return
+	}
+	for page, err := range s.pages(ctx, reqParams, shard.Articles) {
+		if err != nil {
+			if ctx.Err() == nil {
+				send(shardPage{shard: index, err: err})
+			}
+			return
+		}
+		if !send(shardPage{shard: index, page: page}) {
+			return
+		}
+	}
+}
+
+// newBackfillState splits the range between from and to in shards of the given duration, in chronological order.
+func newBackfillState(from, to time.Time, shard time.Duration) BackfillState {
+	if shard <= 0 {
+		shard = to.Sub(from)
+	}
+	var state BackfillState
+	for start := from; start.Before(to); start = start.Add(shard) {
+		end := start.Add(shard)
+		if end.After(to) {
+			end = to
+		}
+		state.Shards = append(state.Shards, ShardState{From: start, To: end})
+	}
+	return state
+}
+
+// withDateWindow sets the from_date and to_date parameters to fetch the articles published between from (inclusive)
+// and to (exclusive). Dates are sent without time if both are at midnight UTC.
+func withDateWindow(from, to time.Time) ArchiveCryptoParams {
+	return func(p requestParams, endpoint endpoint, v *paramValidator) {
+		from, to := from.UTC(), to.UTC()
+		if from.Equal(from.Truncate(24*time.Hour)) && to.Equal(to.Truncate(24*time.Hour)) {
+			p["from_date"] = from.Format(time.DateOnly)
+			p["to_date"] = to.AddDate(0, 0, -1).Format(time.DateOnly)
+			return
+		}
+		p["from_date"] = from.Format(time.DateTime)
+		p["to_date"] = to.Add(-time.Second).Format(time.DateTime)
+	}
+}
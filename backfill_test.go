@@ -0,0 +1,118 @@
+package newsdata_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/sicamois/newsdata"
+	"github.com/sicamois/newsdata/newsdatatest"
+)
+
+func TestBackfill(t *testing.T) {
+	server := newsdatatest.NewServer()
+	defer server.Close()
+	server.AddArticles(newsdatatest.EndpointArchive, newsdatatest.SampleArticles(100)...)
+	client := server.NewClient()
+	from := time.Date(2024, 12, 28, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var articles []*newsdata.NewsArticle
+	progress := 0
+	for article, err := range client.NewsArchive.Backfill(context.Background(), "", from, to, 24*time.Hour, 3,
+		newsdata.WithBackfillProgress(func(newsdata.BackfillProgress) { progress++ })) {
+		if err != nil {
+			t.Fatalf("Error backfilling News Archive: %v", err)
+		}
+		articles = append(articles, article)
+	}
+	// Articles are published every hour: 96 articles in 4 days, in 4 shards of 3 pages.
+	if len(articles) != 96 {
+		t.Fatalf("Invalid number of articles: %d - should be 96", len(articles))
+	}
+	for i := 1; i < len(articles); i++ {
+		if !articles[i].PubDate.Before(articles[i-1].PubDate.Time) {
+			t.Fatalf("Articles should be sorted by publication date, most recent first")
+		}
+	}
+	if progress != 12 {
+		t.Fatalf("Progress should be reported 12 times, got %d", progress)
+	}
+
+	ids := make(map[string]bool)
+	for article, err := range client.NewsArchive.Backfill(context.Background(), "", from, to, 6*time.Hour, 4, newsdata.WithUnordered()) {
+		if err != nil {
+			t.Fatalf("Error backfilling News Archive: %v", err)
+		}
+		ids[article.Id] = true
+	}
+	if len(ids) != 96 {
+		t.Fatalf("Invalid number of unordered articles: %d - should be 96", len(ids))
+	}
+
+	count := 0
+	for article, err := range client.NewsArchive.Backfill(context.Background(), "", from, to, 24*time.Hour, 2,
+		newsdata.WithBackfillParams(newsdata.WithCountries("us"))) {
+		if err != nil {
+			t.Fatalf("Error backfilling News Archive: %v", err)
+		}
+		if !slices.Contains(article.Countries, "us") {
+			t.Fatalf("Article %s should be filtered out: %v", article.Id, article.Countries)
+		}
+		count++
+	}
+	// 1 article out of 5 is from the US.
+	if count != 96/5 {
+		t.Fatalf("Invalid number of filtered articles: %d - should be %d", count, 96/5)
+	}
+}
+
+func TestBackfillResume(t *testing.T) {
+	server := newsdatatest.NewServer()
+	defer server.Close()
+	server.AddArticles(newsdatatest.EndpointArchive, newsdatatest.SampleArticles(100)...)
+	client := server.NewClient(newsdata.WithLogWriter(io.Discard))
+	from := time.Date(2024, 12, 28, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// The second request, i.e. the second page of the most recent shard, fails.
+	server.InjectFault(newsdatatest.EndpointArchive, newsdatatest.Fault{Latency: time.Millisecond})
+	server.InjectFault(newsdatatest.EndpointArchive, newsdatatest.Fault{StatusCode: 400, Code: "UnsupportedParameter", Message: "Invalid parameter"})
+	ids := make(map[string]bool)
+	var backfillErr *newsdata.BackfillError
+	for article, err := range client.NewsArchive.Backfill(context.Background(), "", from, to, 24*time.Hour, 1) {
+		if err != nil {
+			if !errors.As(err, &backfillErr) || !errors.Is(err, newsdata.ErrInvalidParam) {
+				t.Fatalf("Error should be a BackfillError wrapping ErrInvalidParam: %v", err)
+			}
+			break
+		}
+		ids[article.Id] = true
+	}
+	if backfillErr == nil {
+		t.Fatalf("Backfill should fail")
+	}
+	if len(ids) != 82 {
+		t.Fatalf("Invalid number of articles before resuming: %d - should be 82", len(ids))
+	}
+	failed := backfillErr.State.Shards[3]
+	if failed.Done || failed.Error == "" || failed.Articles != 10 || failed.Cursor == nil {
+		t.Fatalf("Invalid state of the failed shard: %+v", failed)
+	}
+
+	for article, err := range client.NewsArchive.Backfill(context.Background(), "", time.Time{}, time.Time{}, 0, 2, newsdata.WithResumeBackfill(backfillErr.State)) {
+		if err != nil {
+			t.Fatalf("Error resuming backfill: %v", err)
+		}
+		if ids[article.Id] {
+			t.Fatalf("Article %s retrieved twice", article.Id)
+		}
+		ids[article.Id] = true
+	}
+	if len(ids) != 96 {
+		t.Fatalf("Invalid number of articles after resuming: %d - should be 96", len(ids))
+	}
+}
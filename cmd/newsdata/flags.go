@@ -0,0 +1,405 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sicamois/newsdata"
+)
+
+// listFlag is a flag accepting comma-separated values. It can be repeated.
+type listFlag []string
+
+func (l *listFlag) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *listFlag) Set(value string) error {
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			*l = append(*l, v)
+		}
+	}
+	return nil
+}
+
+// dateFlag is a flag accepting a date (2006-01-02) or a date and time (RFC 3339).
+type dateFlag struct {
+	time.Time
+}
+
+func (d *dateFlag) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.Format(time.DateOnly)
+}
+
+func (d *dateFlag) Set(value string) error {
+	for _, layout := range []string{time.DateOnly, time.RFC3339} {
+		if t, err := time.Parse(layout, value); err == nil {
+			d.Time = t
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid date %q, expected YYYY-MM-DD or RFC 3339", value)
+}
+
+// timeframeFlag is a flag accepting a duration of at least one minute, the precision of the API.
+type timeframeFlag time.Duration
+
+func (f *timeframeFlag) String() string {
+	if *f == 0 {
+		return ""
+	}
+	return time.Duration(*f).String()
+}
+
+func (f *timeframeFlag) Set(value string) error {
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return err
+	}
+	if d < time.Minute {
+		return fmt.Errorf("invalid timeframe %s, expected at least 1m", d)
+	}
+	*f = timeframeFlag(d)
+	return nil
+}
+
+// rateFlag is a flag accepting a rate limit as a number of requests per period (e.g. 30/1m).
+type rateFlag struct {
+	requests int
+	per      time.Duration
+}
+
+func (r *rateFlag) String() string {
+	if r.requests == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d/%s", r.requests, r.per)
+}
+
+func (r *rateFlag) Set(value string) error {
+	requests, per, ok := strings.Cut(value, "/")
+	if !ok {
+		return fmt.Errorf("invalid rate limit %q, expected requests/period (e.g. 30/1m)", value)
+	}
+	n, err := strconv.Atoi(requests)
+	if err != nil || n <= 0 {
+		return fmt.Errorf("invalid number of requests %q", requests)
+	}
+	d, err := time.ParseDuration(per)
+	if err != nil || d <= 0 {
+		return fmt.Errorf("invalid period %q", per)
+	}
+	r.requests, r.per = n, d
+	return nil
+}
+
+// clientFlags are the flags mapped onto the client options.
+type clientFlags struct {
+	baseURL      string
+	timeout      time.Duration
+	strict       bool
+	retries      int
+	rateLimit    rateFlag
+	creditBudget int
+	location     string
+	logLevel     string
+}
+
+func (f *clientFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.baseURL, "base-url", "", "base URL of the API (default https://newsdata.io/api/1)")
+	fs.DurationVar(&f.timeout, "timeout", 5*time.Second, "timeout of each HTTP request")
+	fs.BoolVar(&f.strict, "strict", false, "fail on invalid parameters instead of dropping them")
+	fs.IntVar(&f.retries, "retries", 0, "number of retries of the requests failing with a transient error")
+	fs.Var(&f.rateLimit, "rate-limit", "maximum number of requests per period, e.g. 30/1m")
+	fs.IntVar(&f.creditBudget, "credit-budget", 0, "maximum number of credits spent, 0 for no limit")
+	fs.StringVar(&f.location, "location", "", "timezone of the publication dates, e.g. Europe/Paris")
+	fs.StringVar(&f.logLevel, "log-level", "warn", "minimum level of the logs: debug, info, warn or error")
+}
+
+// newClient creates a client with the API key of the environment.
+func (f *clientFlags) newClient(env *env) (*newsdata.NewsDataClient, error) {
+	apiKey := env.getenv("NEWSDATA_API_KEY")
+	if apiKey == "" {
+		return nil, &usageError{errors.New("NEWSDATA_API_KEY is not set")}
+	}
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(f.logLevel)); err != nil {
+		return nil, &usageError{fmt.Errorf("invalid log level %q", f.logLevel)}
+	}
+	opts := []newsdata.NewsDataClientOption{
+		newsdata.WithAPIKey(apiKey),
+		newsdata.WithTimeout(f.timeout),
+		newsdata.WithLogWriter(env.stderr),
+		newsdata.WithLogLevel(level),
+	}
+	if f.baseURL != "" {
+		opts = append(opts, newsdata.WithBaseURL(f.baseURL))
+	}
+	if f.strict {
+		opts = append(opts, newsdata.WithStrictParams())
+	}
+	if f.retries > 0 {
+		policy := newsdata.DefaultRetryPolicy
+		policy.MaxAttempts = f.retries + 1
+		opts = append(opts, newsdata.WithRetryPolicy(policy))
+	}
+	if f.rateLimit.requests > 0 {
+		opts = append(opts, newsdata.WithRateLimit(f.rateLimit.requests, f.rateLimit.per))
+	}
+	if f.creditBudget > 0 {
+		opts = append(opts, newsdata.WithCreditBudget(f.creditBudget))
+	}
+	if f.location != "" {
+		loc, err := time.LoadLocation(f.location)
+		if err != nil {
+			return nil, &usageError{fmt.Errorf("invalid location %q: %w", f.location, err)}
+		}
+		opts = append(opts, newsdata.WithLocation(loc))
+	}
+	return newsdata.NewClient(opts...), nil
+}
+
+// outputFlags are the flags selecting what is written on the standard output.
+type outputFlags struct {
+	max    int
+	format string
+	fields listFlag
+}
+
+func (f *outputFlags) register(fs *flag.FlagSet) {
+	fs.IntVar(&f.max, "max", 10, "maximum number of results, 0 for all")
+	fs.StringVar(&f.format, "format", formatTable, "output format: json, jsonl, csv or table")
+	fs.Var(&f.fields, "fields", "comma-separated fields to output, named as in the API responses (default: all, or a summary for table)")
+}
+
+// newsFlags are the flags mapped onto the parameters supported by all the news endpoints.
+type newsFlags struct {
+	title              string
+	metadata           string
+	categories         listFlag
+	excludedCategories listFlag
+	countries          listFlag
+	languages          listFlag
+	domains            listFlag
+	excludedDomains    listFlag
+	domainURLs         listFlag
+	priorityDomain     string
+	excludedFields     listFlag
+	timezone           string
+	onlyFullContent    bool
+	noFullContent      bool
+	onlyImage          bool
+	noImage            bool
+	onlyVideo          bool
+	noVideo            bool
+	size               int
+}
+
+func (f *newsFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.title, "title", "", "query to search in the titles")
+	fs.StringVar(&f.metadata, "metadata", "", "query to search in the metadata")
+	fs.Var(&f.categories, "category", "categories to include, comma-separated")
+	fs.Var(&f.excludedCategories, "exclude-category", "categories to exclude, comma-separated")
+	fs.Var(&f.countries, "country", "countries to include, comma-separated")
+	fs.Var(&f.languages, "language", "languages to include, comma-separated")
+	fs.Var(&f.domains, "domain", "domains to include, comma-separated")
+	fs.Var(&f.excludedDomains, "exclude-domain", "domains to exclude, comma-separated")
+	fs.Var(&f.domainURLs, "domain-url", "domain URLs to include, comma-separated")
+	fs.StringVar(&f.priorityDomain, "priority-domain", "", "priority of the sources: top, medium or low")
+	fs.Var(&f.excludedFields, "exclude-field", "fields to exclude from the API response, comma-separated")
+	fs.StringVar(&f.timezone, "timezone", "", "timezone of the search, e.g. America/New_York")
+	fs.BoolVar(&f.onlyFullContent, "only-full-content", false, "only articles with a full content")
+	fs.BoolVar(&f.noFullContent, "no-full-content", false, "only articles without a full content")
+	fs.BoolVar(&f.onlyImage, "only-image", false, "only articles with an image")
+	fs.BoolVar(&f.noImage, "no-image", false, "only articles without image")
+	fs.BoolVar(&f.onlyVideo, "only-video", false, "only articles with a video")
+	fs.BoolVar(&f.noVideo, "no-video", false, "only articles without video")
+	fs.IntVar(&f.size, "size", 0, "number of articles per page, between 1 and 50 (default: API default)")
+}
+
+func (f *newsFlags) params() []newsdata.NewsRequestParams {
+	var params []newsdata.NewsRequestParams
+	if f.title != "" {
+		params = append(params, newsdata.WithQueryInTitle(f.title))
+	}
+	if f.metadata != "" {
+		params = append(params, newsdata.WithQueryInMetadata(f.metadata))
+	}
+	if len(f.categories) > 0 {
+		params = append(params, newsdata.WithCategories(f.categories...))
+	}
+	if len(f.excludedCategories) > 0 {
+		params = append(params, newsdata.WithCategoriesExlucded(f.excludedCategories...))
+	}
+	if len(f.countries) > 0 {
+		params = append(params, newsdata.WithCountries(f.countries...))
+	}
+	if len(f.languages) > 0 {
+		params = append(params, newsdata.WithLanguages(f.languages...))
+	}
+	if len(f.domains) > 0 {
+		params = append(params, newsdata.WithDomains(f.domains...))
+	}
+	if len(f.excludedDomains) > 0 {
+		params = append(params, newsdata.WithDomainExcluded(f.excludedDomains...))
+	}
+	if len(f.domainURLs) > 0 {
+		params = append(params, newsdata.WithDomainUrls(f.domainURLs...))
+	}
+	if f.priorityDomain != "" {
+		params = append(params, newsdata.WithSourcePriorityDomain(f.priorityDomain))
+	}
+	if len(f.excludedFields) > 0 {
+		params = append(params, newsdata.WithFieldsExcluded(f.excludedFields...))
+	}
+	if f.timezone != "" {
+		params = append(params, newsdata.WithTimezone(f.timezone))
+	}
+	if f.onlyFullContent {
+		params = append(params, newsdata.WithOnlyFullContent())
+	}
+	if f.noFullContent {
+		params = append(params, newsdata.WithNoFullContent())
+	}
+	if f.onlyImage {
+		params = append(params, newsdata.WithOnlyImage())
+	}
+	if f.noImage {
+		params = append(params, newsdata.WithNoImage())
+	}
+	if f.onlyVideo {
+		params = append(params, newsdata.WithOnlyVideo())
+	}
+	if f.noVideo {
+		params = append(params, newsdata.WithNoVideo())
+	}
+	if f.size != 0 {
+		params = append(params, newsdata.WithSize(f.size))
+	}
+	return params
+}
+
+// latestFlags are the flags mapped onto the parameters of the latest, crypto and market news endpoints.
+type latestFlags struct {
+	timeframe        timeframeFlag
+	sentiment        string
+	tags             listFlag
+	removeDuplicates bool
+}
+
+func (f *latestFlags) register(fs *flag.FlagSet) {
+	fs.Var(&f.timeframe, "timeframe", "time window of the search, from 1m up to 48h, e.g. 6h or 90m")
+	f.registerFilters(fs)
+}
+
+// registerFilters registers the flags except the timeframe, which is set by the Watcher when tailing.
+func (f *latestFlags) registerFilters(fs *flag.FlagSet) {
+	fs.StringVar(&f.sentiment, "sentiment", "", "sentiment of the articles: positive, negative or neutral")
+	fs.Var(&f.tags, "tag", "AI tags to include, comma-separated")
+	fs.BoolVar(&f.removeDuplicates, "remove-duplicates", false, "remove the duplicate articles")
+}
+
+func (f *latestFlags) params() []newsdata.LatestCryptoParams {
+	var params []newsdata.LatestCryptoParams
+	if timeframe := time.Duration(f.timeframe); timeframe != 0 {
+		if timeframe%time.Hour == 0 {
+			params = append(params, newsdata.WithTimeframe(int(timeframe/time.Hour), 0))
+		} else {
+			params = append(params, newsdata.WithTimeframe(0, int(timeframe.Round(time.Minute)/time.Minute)))
+		}
+	}
+	if f.sentiment != "" {
+		params = append(params, newsdata.WithSentiment(f.sentiment))
+	}
+	if len(f.tags) > 0 {
+		params = append(params, newsdata.WithTags(f.tags...))
+	}
+	if f.removeDuplicates {
+		params = append(params, newsdata.WithRemoveDuplicates())
+	}
+	return params
+}
+
+// dateFlags are the flags mapped onto the parameters of the news archive and crypto news endpoints.
+type dateFlags struct {
+	from dateFlag
+	to   dateFlag
+}
+
+func (f *dateFlags) register(fs *flag.FlagSet) {
+	fs.Var(&f.from, "from", "start date of the search, YYYY-MM-DD")
+	fs.Var(&f.to, "to", "end date of the search, YYYY-MM-DD")
+}
+
+func (f *dateFlags) params() []newsdata.ArchiveCryptoParams {
+	var params []newsdata.ArchiveCryptoParams
+	if !f.from.IsZero() {
+		params = append(params, newsdata.WithFromDate(f.from.Time))
+	}
+	if !f.to.IsZero() {
+		params = append(params, newsdata.WithToDate(f.to.Time))
+	}
+	return params
+}
+
+// cryptoFlags are the flags mapped onto the parameters of the crypto news endpoint.
+type cryptoFlags struct {
+	coins listFlag
+}
+
+func (f *cryptoFlags) register(fs *flag.FlagSet) {
+	fs.Var(&f.coins, "coin", "coins to include, comma-separated, e.g. btc,eth")
+}
+
+func (f *cryptoFlags) params() []newsdata.CryptoParams {
+	if len(f.coins) == 0 {
+		return nil
+	}
+	return []newsdata.CryptoParams{newsdata.WithCoins(f.coins...)}
+}
+
+// sourcesFlags are the flags mapped onto the parameters of the sources endpoint.
+type sourcesFlags struct {
+	country        string
+	category       string
+	language       string
+	priorityDomain string
+	domainURL      string
+}
+
+func (f *sourcesFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.country, "country", "", "country of the sources")
+	fs.StringVar(&f.category, "category", "", "category of the sources")
+	fs.StringVar(&f.language, "language", "", "language of the sources")
+	fs.StringVar(&f.priorityDomain, "priority-domain", "", "priority of the sources: top, medium or low")
+	fs.StringVar(&f.domainURL, "domain-url", "", "domain URL of the source")
+}
+
+func (f *sourcesFlags) params() []newsdata.SourceRequestParams {
+	var params []newsdata.SourceRequestParams
+	if f.country != "" {
+		params = append(params, newsdata.WithCountry(f.country))
+	}
+	if f.category != "" {
+		params = append(params, newsdata.WithCategory(f.category))
+	}
+	if f.language != "" {
+		params = append(params, newsdata.WithLanguage(f.language))
+	}
+	if f.priorityDomain != "" {
+		params = append(params, newsdata.WithPriorityDomain(f.priorityDomain))
+	}
+	if f.domainURL != "" {
+		params = append(params, newsdata.WithDomainUrl(f.domainURL))
+	}
+	return params
+}
@@ -0,0 +1,349 @@
+// Command newsdata queries the NewsData.io API from the command line.
+//
+// Usage:
+//
+//	newsdata <command> [flags] [query]
+//
+// The commands are latest, archive, crypto, sources and tail. Flags map onto the With* options of the
+// newsdata package (e.g. --country us,fr for WithCountries("us", "fr")), and can be placed before or
+// after the query. The API key is read from the NEWSDATA_API_KEY environment variable.
+//
+//	newsdata latest --language en --max 20 --format table "artificial intelligence"
+//	newsdata archive --from 2024-01-01 --to 2024-02-01 --format csv --fields pubDate,title,link bitcoin
+//	newsdata sources --country fr --format jsonl
+//	newsdata tail --language en --bell outage,breach --exec ./notify.sh cybersecurity
+//
+// tail follows the latest news like tail -f: it polls the latest endpoint with a newsdata.Watcher and prints
+// a one-line summary of each new article, optionally ringing the terminal bell on keywords and running a
+// command with the article as JSON on its standard input.
+//
+// On failure, the error (e.g. the message of the *newsdata.APIError) is printed on the standard error,
+// and the command exits with status 1, or 2 for invalid arguments.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/sicamois/newsdata"
+)
+
+// Exit statuses of the command.
+const (
+	exitOK    = 0
+	exitError = 1 // The request failed
+	exitUsage = 2 // The arguments are invalid
+)
+
+// Default fields of the table format.
+var (
+	defaultArticleFields = []string{"pubDate", "source_id", "title", "link"}
+	defaultSourceFields  = []string{"id", "name", "url", "priority", "country"}
+)
+
+// command is a subcommand of the CLI.
+type command struct {
+	name    string
+	summary string
+	run     func(ctx context.Context, env *env, args []string) error
+}
+
+var commands = []command{
+	{"latest", "Fetch the latest news (past 48 hours)", runLatest},
+	{"archive", "Search the news archive", runArchive},
+	{"crypto", "Fetch crypto news", runCrypto},
+	{"sources", "List the news sources", runSources},
+	{"tail", "Follow the latest news, printing new articles as they appear", runTail},
+}
+
+// env is the environment of a command.
+type env struct {
+	stdout io.Writer
+	stderr io.Writer
+	getenv func(string) string
+}
+
+// usageError is an error caused by invalid arguments.
+type usageError struct {
+	err error
+}
+
+func (e *usageError) Error() string {
+	return e.err.Error()
+}
+
+func (e *usageError) Unwrap() error {
+	return e.err
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	os.Exit(run(ctx, os.Args[1:], os.Stdout, os.Stderr, os.Getenv))
+}
+
+// run runs the command line args and returns the exit status.
+func run(ctx context.Context, args []string, stdout, stderr io.Writer, getenv func(string) string) int {
+	if len(args) == 0 {
+		usage(stderr)
+		return exitUsage
+	}
+	switch args[0] {
+	case "help", "-h", "-help", "--help":
+		usage(stdout)
+		return exitOK
+	}
+	for _, cmd := range commands {
+		if cmd.name != args[0] {
+			continue
+		}
+		err := cmd.run(ctx, &env{stdout: stdout, stderr: stderr, getenv: getenv}, args[1:])
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "newsdata %s: %s\n", cmd.name, errorMessage(err))
+			var usageErr *usageError
+			if errors.As(err, &usageErr) {
+				return exitUsage
+			}
+			return exitError
+		}
+		return exitOK
+	}
+	fmt.Fprintf(stderr, "newsdata: unknown command %q\n\n", args[0])
+	usage(stderr)
+	return exitUsage
+}
+
+// usage prints the list of commands.
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: newsdata <command> [flags] [query]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-10s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, `Run "newsdata <command> -h" for the flags of a command. The API key is read from NEWSDATA_API_KEY.`)
+}
+
+// errorMessage returns the message of err, reduced to the typed error of the newsdata package when there is one.
+func errorMessage(err error) string {
+	var apiErr *newsdata.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Error()
+	}
+	var paramErr *newsdata.ParamError
+	if errors.As(err, &paramErr) {
+		return paramErr.Error()
+	}
+	return err.Error()
+}
+
+// newFlagSet creates the flag set of a command.
+func newFlagSet(env *env, name string, synopsis string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(env.stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: newsdata %s %s\n\nFlags:\n", name, synopsis)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseArgs parses the flags of args, which may be interleaved with positional arguments, and returns the positional arguments.
+// Arguments after "--" are positional.
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, &usageError{err}
+		}
+		if consumed := len(args) - fs.NArg(); consumed > 0 && args[consumed-1] == "--" {
+			return append(positional, fs.Args()...), nil
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// newsCommand holds the flags shared by the news commands.
+type newsCommand struct {
+	fs     *flag.FlagSet
+	client clientFlags
+	output outputFlags
+	news   newsFlags
+}
+
+func newNewsCommand(env *env, name string) *newsCommand {
+	c := &newsCommand{fs: newFlagSet(env, name, "[flags] [query]")}
+	c.client.register(c.fs)
+	c.output.register(c.fs)
+	c.news.register(c.fs)
+	return c
+}
+
+// parse parses args and returns the query.
+func (c *newsCommand) parse(args []string) (string, error) {
+	positional, err := parseArgs(c.fs, args)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(positional, " "), nil
+}
+
+func runLatest(ctx context.Context, env *env, args []string) error {
+	c := newNewsCommand(env, "latest")
+	var latest latestFlags
+	latest.register(c.fs)
+	query, err := c.parse(args)
+	if err != nil {
+		return err
+	}
+	client, err := c.client.newClient(env)
+	if err != nil {
+		return err
+	}
+	var params []newsdata.LatestNewsParam
+	for _, p := range c.news.params() {
+		params = append(params, p)
+	}
+	for _, p := range latest.params() {
+		params = append(params, p)
+	}
+	return writeArticles(env, c.output, client.LatestNews.All(ctx, query, params...))
+}
+
+func runArchive(ctx context.Context, env *env, args []string) error {
+	c := newNewsCommand(env, "archive")
+	var dates dateFlags
+	dates.register(c.fs)
+	query, err := c.parse(args)
+	if err != nil {
+		return err
+	}
+	client, err := c.client.newClient(env)
+	if err != nil {
+		return err
+	}
+	var params []newsdata.NewsArchiveParam
+	for _, p := range c.news.params() {
+		params = append(params, p)
+	}
+	for _, p := range dates.params() {
+		params = append(params, p)
+	}
+	return writeArticles(env, c.output, client.NewsArchive.All(ctx, query, params...))
+}
+
+func runCrypto(ctx context.Context, env *env, args []string) error {
+	c := newNewsCommand(env, "crypto")
+	var (
+		latest latestFlags
+		dates  dateFlags
+		crypto cryptoFlags
+	)
+	latest.register(c.fs)
+	dates.register(c.fs)
+	crypto.register(c.fs)
+	query, err := c.parse(args)
+	if err != nil {
+		return err
+	}
+	client, err := c.client.newClient(env)
+	if err != nil {
+		return err
+	}
+	var params []newsdata.CryptoNewsParam
+	for _, p := range c.news.params() {
+		params = append(params, p)
+	}
+	for _, p := range latest.params() {
+		params = append(params, p)
+	}
+	for _, p := range dates.params() {
+		params = append(params, p)
+	}
+	for _, p := range crypto.params() {
+		params = append(params, p)
+	}
+	return writeArticles(env, c.output, client.CryptoNews.All(ctx, query, params...))
+}
+
+func runSources(ctx context.Context, env *env, args []string) error {
+	fs := newFlagSet(env, "sources", "[flags]")
+	var (
+		clientFlags  clientFlags
+		outputFlags  outputFlags
+		sourcesFlags sourcesFlags
+	)
+	clientFlags.register(fs)
+	outputFlags.register(fs)
+	sourcesFlags.register(fs)
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return &usageError{fmt.Errorf("unexpected arguments: %s", strings.Join(positional, " "))}
+	}
+	w, err := newRecordWriter[newsdata.Source](env.stdout, outputFlags.format, outputFlags.fields, defaultSourceFields)
+	if err != nil {
+		return err
+	}
+	client, err := clientFlags.newClient(env)
+	if err != nil {
+		return err
+	}
+	sources, err := client.Sources.Get(ctx, sourcesFlags.params()...)
+	if err != nil {
+		return err
+	}
+	if outputFlags.max > 0 && len(sources) > outputFlags.max {
+		sources = sources[:outputFlags.max]
+	}
+	for _, source := range sources {
+		if err := w.Write(source); err != nil {
+			return err
+		}
+	}
+	return w.Close()
+}
+
+// writeArticles writes at most output.max articles of seq to the standard output, as they are retrieved.
+func writeArticles(env *env, output outputFlags, seq iter.Seq2[*newsdata.NewsArticle, error]) error {
+	w, err := newRecordWriter[newsdata.NewsArticle](env.stdout, output.format, output.fields, defaultArticleFields)
+	if err != nil {
+		return err
+	}
+	count := 0
+	for article, err := range seq {
+		if err != nil {
+			w.Close() // Keep the articles already written
+			return err
+		}
+		if err := w.Write(article); err != nil {
+			return err
+		}
+		count++
+		if output.max > 0 && count == output.max {
+			break
+		}
+	}
+	return w.Close()
+}
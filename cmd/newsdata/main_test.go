@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/sicamois/newsdata"
+	"github.com/sicamois/newsdata/newsdatatest"
+)
+
+func newServer(t *testing.T) *newsdatatest.Server {
+	server := newsdatatest.NewServer()
+	t.Cleanup(server.Close)
+	server.AddArticles(newsdatatest.EndpointLatest, newsdatatest.SampleArticles(30)...)
+	server.AddArticles(newsdatatest.EndpointArchive, newsdatatest.SampleArticles(30)...)
+	server.AddArticles(newsdatatest.EndpointCrypto, newsdatatest.SampleArticles(30)...)
+	server.AddSources(newsdatatest.SampleSources()...)
+	return server
+}
+
+// runCLI runs the command line with the API key of the fake server.
+func runCLI(t *testing.T, server *newsdatatest.Server, args ...string) (string, string, int) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	getenv := func(key string) string {
+		if key == "NEWSDATA_API_KEY" {
+			return newsdatatest.APIKey
+		}
+		return ""
+	}
+	args = append(args[:1:1], append([]string{"--base-url", server.URL}, args[1:]...)...)
+	status := run(context.Background(), args, &stdout, &stderr, getenv)
+	return stdout.String(), stderr.String(), status
+}
+
+func TestLatestJSONL(t *testing.T) {
+	server := newServer(t)
+	stdout, stderr, status := runCLI(t, server, "latest", "--country", "gb", "--format", "jsonl", "--max", "3", "--fields", "article_id,country", "technology")
+	if status != exitOK {
+		t.Fatalf("Invalid exit status: %d - stderr: %s", status, stderr)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Invalid number of lines: %d - should be 3", len(lines))
+	}
+	for _, line := range lines {
+		var article map[string]any
+		if err := json.Unmarshal([]byte(line), &article); err != nil {
+			t.Fatalf("Invalid JSON line %s: %v", line, err)
+		}
+		if len(article) != 2 || article["country"].([]any)[0] != "gb" {
+			t.Fatalf("Invalid article: %s", line)
+		}
+	}
+	query := server.Requests()[0].Query
+	if query.Get("q") != "technology" || query.Get("country") != "gb" {
+		t.Fatalf("Invalid request parameters: %v", query)
+	}
+}
+
+func TestArchiveJSON(t *testing.T) {
+	server := newServer(t)
+	stdout, stderr, status := runCLI(t, server, "archive", "--format", "json", "--max", "0", "--from", "2024-12-31", "--to", "2025-01-01")
+	if status != exitOK {
+		t.Fatalf("Invalid exit status: %d - stderr: %s", status, stderr)
+	}
+	var articles []newsdata.NewsArticle
+	if err := json.Unmarshal([]byte(stdout), &articles); err != nil {
+		t.Fatalf("Invalid JSON output: %v", err)
+	}
+	if len(articles) != 25 {
+		t.Fatalf("Invalid number of articles: %d - should be 25", len(articles))
+	}
+}
+
+func TestCryptoCSV(t *testing.T) {
+	server := newServer(t)
+	stdout, stderr, status := runCLI(t, server, "crypto", "--coin", "btc", "--format", "csv", "--fields", "article_id,coin,pubDate")
+	if status != exitOK {
+		t.Fatalf("Invalid exit status: %d - stderr: %s", status, stderr)
+	}
+	records, err := csv.NewReader(strings.NewReader(stdout)).ReadAll()
+	if err != nil {
+		t.Fatalf("Invalid CSV output: %v", err)
+	}
+	if len(records) != 7 || strings.Join(records[0], ",") != "article_id,coin,pubDate" {
+		t.Fatalf("Invalid CSV output: %v", records)
+	}
+	if records[1][1] != "btc" || records[1][2] != "2025-01-01T00:00:00Z" {
+		t.Fatalf("Invalid CSV record: %v", records[1])
+	}
+}
+
+func TestSourcesTable(t *testing.T) {
+	server := newServer(t)
+	stdout, stderr, status := runCLI(t, server, "sources")
+	if status != exitOK {
+		t.Fatalf("Invalid exit status: %d - stderr: %s", status, stderr)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) != len(newsdatatest.SampleSources())+1 || !strings.HasPrefix(lines[0], "ID  ") {
+		t.Fatalf("Invalid table:\n%s", stdout)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	server := newServer(t)
+	server.SetAPIKey("another-key")
+	_, stderr, status := runCLI(t, server, "latest")
+	if status != exitError {
+		t.Fatalf("Invalid exit status: %d - should be %d", status, exitError)
+	}
+	if stderr != "newsdata latest: newsdata: API key invalid - status: 401, code: Unauthorized, endpoint: latest\n" {
+		t.Fatalf("Invalid error message: %s", stderr)
+	}
+
+	for _, args := range [][]string{
+		{"latest", "--format", "xml"},
+		{"latest", "--fields", "unknown"},
+		{"archive", "--from", "yesterday"},
+		{"latest", "--timeframe", "20s"},
+		{"sources", "query"},
+		{"unknown"},
+	} {
+		if _, _, status := runCLI(t, server, args...); status != exitUsage {
+			t.Fatalf("Invalid exit status for %v: %d - should be %d", args, status, exitUsage)
+		}
+	}
+
+	var stderrBuf bytes.Buffer
+	status = run(context.Background(), []string{"latest"}, &bytes.Buffer{}, &stderrBuf, func(string) string { return "" })
+	if status != exitUsage || !strings.Contains(stderrBuf.String(), "NEWSDATA_API_KEY") {
+		t.Fatalf("Missing API key should be a usage error, got %d: %s", status, stderrBuf.String())
+	}
+}
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+	"unicode/utf8"
+)
+
+// Output formats.
+const (
+	formatJSON  = "json"
+	formatJSONL = "jsonl"
+	formatCSV   = "csv"
+	formatTable = "table"
+)
+
+// maxTableWidth is the maximum width of a column of the table format, in runes.
+const maxTableWidth = 80
+
+// recordWriter writes records (articles or sources) in an output format, as they are retrieved.
+type recordWriter[T any] struct {
+	w       io.Writer
+	format  string
+	fields  []string       // Fields written, nil to write all the fields in JSON
+	indexes map[string]int // Index of the struct field of each field name
+	csv     *csv.Writer
+	table   *tabwriter.Writer
+	count   int
+}
+
+// newRecordWriter creates a writer of T records in format. fields are named as the JSON fields of T; if empty,
+// all the fields are written, except in the table format where defaultFields are written.
+func newRecordWriter[T any](w io.Writer, format string, fields []string, defaultFields []string) (*recordWriter[T], error) {
+	rw := &recordWriter[T]{w: w, format: format, indexes: fieldIndexes(reflect.TypeFor[T]())}
+	for _, field := range fields {
+		if _, ok := rw.indexes[field]; !ok {
+			return nil, &usageError{fmt.Errorf("unknown field %q, available fields: %s", field, strings.Join(fieldNames(reflect.TypeFor[T]()), ", "))}
+		}
+	}
+	rw.fields = fields
+	switch format {
+	case formatJSON, formatJSONL:
+	case formatCSV:
+		if len(rw.fields) == 0 {
+			rw.fields = fieldNames(reflect.TypeFor[T]())
+		}
+		rw.csv = csv.NewWriter(w)
+	case formatTable:
+		if len(rw.fields) == 0 {
+			rw.fields = defaultFields
+		}
+		rw.table = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	default:
+		return nil, &usageError{fmt.Errorf("unknown format %q, expected json, jsonl, csv or table", format)}
+	}
+	return rw, nil
+}
+
+// Write writes a record.
+func (rw *recordWriter[T]) Write(record *T) error {
+	defer func() { rw.count++ }()
+	switch rw.format {
+	case formatJSON, formatJSONL:
+		data, err := rw.marshalJSON(record)
+		if err != nil {
+			return err
+		}
+		prefix := ""
+		if rw.format == formatJSON {
+			prefix = ",\n  "
+			if rw.count == 0 {
+				prefix = "[\n  "
+			}
+		}
+		_, err = fmt.Fprintf(rw.w, "%s%s", prefix, data)
+		if rw.format == formatJSONL && err == nil {
+			_, err = io.WriteString(rw.w, "\n")
+		}
+		return err
+	case formatCSV:
+		if rw.count == 0 {
+			if err := rw.csv.Write(rw.fields); err != nil {
+				return err
+			}
+		}
+		values, err := rw.values(record)
+		if err != nil {
+			return err
+		}
+		return rw.csv.Write(values)
+	default:
+		if rw.count == 0 {
+			header := make([]string, len(rw.fields))
+			for i, field := range rw.fields {
+				header[i] = strings.ToUpper(field)
+			}
+			if _, err := fmt.Fprintln(rw.table, strings.Join(header, "\t")); err != nil {
+				return err
+			}
+		}
+		values, err := rw.values(record)
+		if err != nil {
+			return err
+		}
+		for i, value := range values {
+			values[i] = truncate(strings.Join(strings.Fields(value), " "), maxTableWidth)
+		}
+		_, err = fmt.Fprintln(rw.table, strings.Join(values, "\t"))
+		return err
+	}
+}
+
+// Close terminates the output. It does not close the underlying writer.
+func (rw *recordWriter[T]) Close() error {
+	switch rw.format {
+	case formatJSON:
+		if rw.count == 0 {
+			_, err := io.WriteString(rw.w, "[]\n")
+			return err
+		}
+		_, err := io.WriteString(rw.w, "\n]\n")
+		return err
+	case formatCSV:
+		rw.csv.Flush()
+		return rw.csv.Error()
+	case formatTable:
+		return rw.table.Flush()
+	}
+	return nil
+}
+
+// marshalJSON marshals the selected fields of record, in the order of the fields.
+func (rw *recordWriter[T]) marshalJSON(record *T) ([]byte, error) {
+	data, err := json.Marshal(record)
+	if err != nil || len(rw.fields) == 0 {
+		return data, err
+	}
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, field := range rw.fields {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, _ := json.Marshal(field)
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(values[field])
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// values returns the text of the selected fields of record.
+func (rw *recordWriter[T]) values(record *T) ([]string, error) {
+	v := reflect.ValueOf(record).Elem()
+	values := make([]string, len(rw.fields))
+	for i, field := range rw.fields {
+		value, err := textValue(v.Field(rw.indexes[field]))
+		if err != nil {
+			return nil, fmt.Errorf("formatting field %s: %w", field, err)
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// textValue returns the text form of a field: its MarshalText result, comma-separated values for a slice of strings,
+// or its default format.
+func textValue(v reflect.Value) (string, error) {
+	if m, ok := v.Interface().(encoding.TextMarshaler); ok {
+		text, err := m.MarshalText()
+		return string(text), err
+	}
+	if values, ok := v.Interface().([]string); ok {
+		return strings.Join(values, ","), nil
+	}
+	return fmt.Sprint(v.Interface()), nil
+}
+
+// fieldIndexes returns the index of each exported field of the struct t, by JSON name.
+func fieldIndexes(t reflect.Type) map[string]int {
+	indexes := make(map[string]int)
+	for i := range t.NumField() {
+		if name := jsonName(t.Field(i)); name != "" {
+			indexes[name] = i
+		}
+	}
+	return indexes
+}
+
+// fieldNames returns the JSON names of the exported fields of the struct t, in their declaration order.
+func fieldNames(t reflect.Type) []string {
+	var names []string
+	for i := range t.NumField() {
+		if name := jsonName(t.Field(i)); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// jsonName returns the JSON name of a struct field, or an empty string if it is not marshaled.
+func jsonName(field reflect.StructField) string {
+	if !field.IsExported() {
+		return ""
+	}
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return field.Name
+	}
+	return name
+}
+
+// truncate shortens s to at most width runes, ending with an ellipsis if it was truncated.
+func truncate(s string, width int) string {
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:width-1]) + "…"
+}
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/sicamois/newsdata"
+)
+
+// ANSI escape sequences of the colorized output.
+const (
+	ansiReset  = "\033[0m"
+	ansiBold   = "\033[1m"
+	ansiDim    = "\033[2m"
+	ansiRed    = "\033[31m"
+	ansiYellow = "\033[33m"
+	ansiCyan   = "\033[36m"
+)
+
+// Output formats of the tail command, in addition to formatJSONL.
+const formatLine = "line"
+
+// tailWatch is the name of the watch polled by the tail command.
+const tailWatch = "tail"
+
+// tailSeenTTL is the time the articles are remembered in the file set with --seen-file.
+// It matches the maximum timeframe of the latest news endpoint.
+const tailSeenTTL = 48 * time.Hour
+
+// tailFlags are the flags specific to the tail command.
+type tailFlags struct {
+	minInterval time.Duration
+	maxInterval time.Duration
+	lookback    time.Duration
+	seenFile    string
+	max         int
+	format      string
+	color       string
+	bell        listFlag
+	exec        string
+}
+
+func (f *tailFlags) register(fs *flag.FlagSet) {
+	fs.DurationVar(&f.minInterval, "interval", time.Minute, "minimum interval between two polls")
+	fs.DurationVar(&f.maxInterval, "max-interval", 15*time.Minute, "maximum interval between two polls, when no article is found")
+	fs.DurationVar(&f.lookback, "lookback", time.Hour, "time window of the first poll, up to 48h")
+	fs.StringVar(&f.seenFile, "seen-file", "", "file remembering the articles printed, so that they are not printed again after a restart")
+	fs.IntVar(&f.max, "max", 0, "exit after this number of articles, 0 to follow until interrupted")
+	fs.StringVar(&f.format, "format", formatLine, "output format: line or jsonl")
+	fs.StringVar(&f.color, "color", "auto", "colorize the output: auto, always or never")
+	fs.Var(&f.bell, "bell", "keywords ringing the terminal bell when found in an article, comma-separated (line format only)")
+	fs.StringVar(&f.exec, "exec", "", "shell command run for each article, with the article as JSON on its standard input")
+}
+
+func runTail(ctx context.Context, env *env, args []string) error {
+	fs := newFlagSet(env, "tail", "[flags] [query]")
+	var (
+		client clientFlags
+		news   newsFlags
+		latest latestFlags
+		tail   tailFlags
+	)
+	client.register(fs)
+	news.register(fs)
+	latest.registerFilters(fs)
+	tail.register(fs)
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	query := strings.Join(positional, " ")
+	if tail.format != formatLine && tail.format != formatJSONL {
+		return &usageError{fmt.Errorf("unknown format %q, expected line or jsonl", tail.format)}
+	}
+	color, err := useColor(tail.color, env)
+	if err != nil {
+		return err
+	}
+	c, err := client.newClient(env)
+	if err != nil {
+		return err
+	}
+
+	opts := []newsdata.WatcherOption{
+		newsdata.WithPollInterval(tail.minInterval, tail.maxInterval),
+		newsdata.WithLookback(tail.lookback),
+	}
+	if tail.seenFile != "" {
+		store, err := newsdata.OpenFileSeenStore(tail.seenFile, tailSeenTTL)
+		if err != nil {
+			return err
+		}
+		defer store.Close()
+		opts = append(opts, newsdata.WithSeenStore(store))
+	}
+	var params []newsdata.LatestNewsParam
+	for _, p := range news.params() {
+		params = append(params, p)
+	}
+	for _, p := range latest.params() {
+		params = append(params, p)
+	}
+	watcher := c.NewWatcher(opts...)
+	if err := watcher.Add(tailWatch, query, params...); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stopped := make(chan struct{})
+	go func() {
+		watcher.Run(ctx)
+		close(stopped)
+	}()
+	defer func() {
+		// The pending events are not received: they are not recorded as seen, and are printed after a restart.
+		cancel()
+		<-stopped
+	}()
+
+	printer := &tailPrinter{env: env, format: tail.format, color: color, bell: tail.bell, exec: tail.exec}
+	count := 0
+	for event := range watcher.Events() {
+		if event.Err != nil {
+			if isFatal(event.Err) {
+				return event.Err
+			}
+			printer.printError(event.Err)
+			continue
+		}
+		if err := printer.print(ctx, event.Article); err != nil {
+			return err
+		}
+		count++
+		if tail.max > 0 && count == tail.max {
+			return nil
+		}
+	}
+	return nil
+}
+
+// isFatal reports whether a poll error cannot be fixed by polling again, e.g. an invalid API key or parameter.
+func isFatal(err error) bool {
+	var paramErr *newsdata.ParamError
+	return errors.Is(err, newsdata.ErrUnauthorized) || errors.Is(err, newsdata.ErrInvalidParam) ||
+		errors.Is(err, newsdata.ErrPlanRestricted) || errors.As(err, &paramErr)
+}
+
+// useColor reports whether the output is colorized. In auto mode, it is colorized if the standard output
+// is a terminal and NO_COLOR is not set.
+func useColor(mode string, env *env) (bool, error) {
+	switch mode {
+	case "always":
+		return true, nil
+	case "never":
+		return false, nil
+	case "auto":
+		if env.getenv("NO_COLOR") != "" {
+			return false, nil
+		}
+		f, ok := env.stdout.(*os.File)
+		if !ok {
+			return false, nil
+		}
+		info, err := f.Stat()
+		return err == nil && info.Mode()&os.ModeCharDevice != 0, nil
+	}
+	return false, &usageError{fmt.Errorf("unknown color mode %q, expected auto, always or never", mode)}
+}
+
+// tailPrinter prints the articles found by the tail command, and runs the --exec hook.
+type tailPrinter struct {
+	env    *env
+	format string
+	color  bool
+	bell   []string
+	exec   string
+}
+
+// print prints an article and runs the hook.
+func (p *tailPrinter) print(ctx context.Context, article *newsdata.NewsArticle) error {
+	data, err := json.Marshal(article)
+	if err != nil {
+		return err
+	}
+	match := p.matches(article)
+	if p.format == formatJSONL {
+		_, err = fmt.Fprintf(p.env.stdout, "%s\n", data)
+	} else {
+		_, err = io.WriteString(p.env.stdout, p.summary(article, match))
+	}
+	if err != nil {
+		return err
+	}
+	if match && p.format == formatLine {
+		// The bell is best effort, and would corrupt the JSON lines.
+		_, _ = io.WriteString(p.env.stdout, "\a")
+	}
+	if p.exec != "" {
+		if err := p.runHook(ctx, data); err != nil && ctx.Err() == nil {
+			p.printError(fmt.Errorf("exec hook for article %s: %w", article.Id, err))
+		}
+	}
+	return nil
+}
+
+// summary returns the one-line summary of an article: publication time, source, title and link.
+func (p *tailPrinter) summary(article *newsdata.NewsArticle, match bool) string {
+	title := strings.Join(strings.Fields(article.Title), " ")
+	titleStyle := ansiBold
+	if match {
+		titleStyle = ansiBold + ansiYellow
+	}
+	return fmt.Sprintf("%s %s %s %s\n",
+		p.style(ansiDim, article.PubDate.Local().Format(time.DateTime)),
+		p.style(ansiCyan, article.SourceId),
+		p.style(titleStyle, title),
+		p.style(ansiDim, article.Link))
+}
+
+// printError prints a poll or hook error on the standard error.
+func (p *tailPrinter) printError(err error) {
+	fmt.Fprintf(p.env.stderr, "%s\n", p.style(ansiRed, "newsdata tail: "+errorMessage(err)))
+}
+
+// style wraps s in an ANSI style if the output is colorized.
+func (p *tailPrinter) style(style string, s string) string {
+	if !p.color {
+		return s
+	}
+	return style + s + ansiReset
+}
+
+// matches reports whether one of the --bell keywords is in the title, description or keywords of an article, ignoring case.
+func (p *tailPrinter) matches(article *newsdata.NewsArticle) bool {
+	if len(p.bell) == 0 {
+		return false
+	}
+	text := strings.ToLower(strings.Join([]string{article.Title, article.Description, strings.Join(article.Keywords, " ")}, " "))
+	for _, keyword := range p.bell {
+		if strings.Contains(text, strings.ToLower(keyword)) {
+			return true
+		}
+	}
+	return false
+}
+
+// runHook runs the --exec command with the article as JSON on its standard input.
+func (p *tailPrinter) runHook(ctx context.Context, data []byte) error {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", p.exec)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", p.exec)
+	}
+	cmd.Stdin = bytes.NewReader(append(data, '\n'))
+	cmd.Stdout = p.env.stdout
+	cmd.Stderr = p.env.stderr
+	return cmd.Run()
+}
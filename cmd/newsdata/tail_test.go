@@ -0,0 +1,144 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sicamois/newsdata"
+	"github.com/sicamois/newsdata/newsdatatest"
+)
+
+// tailArgs are the arguments of a tail command polling the fake server quickly.
+var tailArgs = []string{"tail", "--interval", "10ms", "--max-interval", "20ms", "--lookback", "6h"}
+
+func TestTail(t *testing.T) {
+	server := newServer(t)
+	server.SetNow(func() time.Time { return time.Date(2025, 1, 1, 1, 0, 0, 0, time.UTC) })
+
+	stdout, stderr, status := runCLI(t, server, append(tailArgs, "--max", "3", "--color", "always", "--bell", "SAMPLE")...)
+	if status != exitOK {
+		t.Fatalf("Invalid exit status: %d - stderr: %s", status, stderr)
+	}
+	lines := strings.Split(strings.TrimSpace(strings.ReplaceAll(stdout, "\a", "")), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Invalid number of lines: %d - should be 3:\n%s", len(lines), stdout)
+	}
+	if strings.Count(stdout, "\a") != 3 || !strings.Contains(lines[0], ansiBold+ansiYellow+"Sample") {
+		t.Fatalf("Matching articles should ring the bell and be highlighted:\n%q", stdout)
+	}
+
+	stdout, stderr, status = runCLI(t, server, append(tailArgs, "--max", "3", "--format", "jsonl", "--bell", "SAMPLE")...)
+	if status != exitOK {
+		t.Fatalf("Invalid exit status: %d - stderr: %s", status, stderr)
+	}
+	if strings.Contains(stdout, "\a") {
+		t.Fatalf("The bell should not be rung in JSON lines:\n%q", stdout)
+	}
+
+	stdout, stderr, status = runCLI(t, server, append(tailArgs, "--max", "2", "--color", "never", "--country", "us")...)
+	if status != exitOK {
+		t.Fatalf("Invalid exit status: %d - stderr: %s", status, stderr)
+	}
+	if strings.ContainsAny(stdout, "\033\a") || strings.Count(stdout, "\n") != 2 {
+		t.Fatalf("Output should be 2 plain lines:\n%q", stdout)
+	}
+	for _, line := range strings.Split(strings.TrimSpace(stdout), "\n") {
+		if !strings.Contains(line, " source0 Sample ") {
+			t.Fatalf("Invalid summary: %s", line)
+		}
+	}
+}
+
+func TestTailExec(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("The hook uses a POSIX shell")
+	}
+	server := newServer(t)
+	server.SetNow(func() time.Time { return time.Date(2025, 1, 1, 1, 0, 0, 0, time.UTC) })
+	dir := t.TempDir()
+	hookOutput := filepath.Join(dir, "articles.jsonl")
+	seenFile := filepath.Join(dir, "seen.log")
+
+	// The 6 articles published in the lookback.
+	args := append(tailArgs, "--max", "6", "--format", "jsonl", "--seen-file", seenFile, "--exec", "cat >> "+hookOutput)
+	if _, stderr, status := runCLI(t, server, args...); status != exitOK {
+		t.Fatalf("Invalid exit status: %d - stderr: %s", status, stderr)
+	}
+	data, err := os.ReadFile(hookOutput)
+	if err != nil {
+		t.Fatalf("Error reading hook output: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 6 {
+		t.Fatalf("Hook should run 6 times, got %d", len(lines))
+	}
+	for _, line := range lines {
+		var article newsdata.NewsArticle
+		if err := json.Unmarshal([]byte(line), &article); err != nil {
+			t.Fatalf("Invalid hook input %s: %v", line, err)
+		}
+	}
+
+	// After a restart, the articles already printed are skipped.
+	server.AddArticles(newsdatatest.EndpointLatest, newsdata.NewsArticle{
+		Id:      "new-article",
+		Title:   "New article",
+		PubDate: newsdata.DateTime{Time: time.Date(2025, 1, 1, 0, 30, 0, 0, time.UTC)},
+	})
+	stdout, stderr, status := runCLI(t, server, append(tailArgs, "--max", "1", "--format", "jsonl", "--seen-file", seenFile)...)
+	if status != exitOK {
+		t.Fatalf("Invalid exit status: %d - stderr: %s", status, stderr)
+	}
+	var article newsdata.NewsArticle
+	if err := json.Unmarshal([]byte(stdout), &article); err != nil {
+		t.Fatalf("Invalid output %s: %v", stdout, err)
+	}
+	if article.Id != "new-article" {
+		t.Fatalf("Article %s should not be printed again", article.Id)
+	}
+}
+
+func TestTailFatalError(t *testing.T) {
+	server := newServer(t)
+	server.SetAPIKey("another-key")
+	_, stderr, status := runCLI(t, server, append(tailArgs, "--color", "never")...)
+	if status != exitError || !strings.Contains(stderr, "code: Unauthorized") {
+		t.Fatalf("Invalid API key should stop tail, got %d: %s", status, stderr)
+	}
+}
+
+func TestTailMaxSeenFile(t *testing.T) {
+	server := newServer(t)
+	server.SetNow(func() time.Time { return time.Date(2025, 1, 1, 1, 0, 0, 0, time.UTC) })
+	args := append(tailArgs, "--max", "3", "--format", "jsonl", "--seen-file", filepath.Join(t.TempDir(), "seen.log"))
+
+	// The 6 articles published in the lookback are printed over 2 runs, without being lost or printed twice.
+	printed := map[string]bool{}
+	for range 2 {
+		stdout, stderr, status := runCLI(t, server, args...)
+		if status != exitOK {
+			t.Fatalf("Invalid exit status: %d - stderr: %s", status, stderr)
+		}
+		for _, line := range strings.Split(strings.TrimSpace(stdout), "\n") {
+			var article newsdata.NewsArticle
+			if err := json.Unmarshal([]byte(line), &article); err != nil {
+				t.Fatalf("Invalid output %s: %v", line, err)
+			}
+			if printed[article.Id] {
+				t.Fatalf("Article %s should not be printed again", article.Id)
+			}
+			printed[article.Id] = true
+		}
+	}
+	for i := range 6 {
+		if id := fmt.Sprintf("article-%04d", i); !printed[id] {
+			t.Fatalf("Article %s should be printed: %v", id, printed)
+		}
+	}
+}
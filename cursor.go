@@ -0,0 +1,99 @@
+package newsdata
+
+import (
+	"fmt"
+	"maps"
+)
+
+// Cursor is the position of a news request after a page, from which the request can be resumed
+// with WithResumeFrom.
+//
+// It can be serialized to JSON, e.g. to persist it between two runs of a batch job.
+type Cursor struct {
+	Endpoint         string            `json:"endpoint"`          // Endpoint of the request (e.g. "archive")
+	Params           map[string]string `json:"params"`            // Query parameters of the request, without the page token
+	PageToken        string            `json:"page_token"`        // Token of the next page to fetch
+	ArticlesConsumed int               `json:"articles_consumed"` // Number of articles retrieved before the next page
+}
+
+// newCursor creates the cursor to resume a request after a page.
+func newCursor(endpoint endpoint, params requestParams, pageToken string, articlesConsumed int) *Cursor {
+	cursorParams := maps.Clone(map[string]string(params))
+	delete(cursorParams, "page")
+	return &Cursor{
+		Endpoint:         string(endpoint),
+		Params:           cursorParams,
+		PageToken:        pageToken,
+		ArticlesConsumed: articlesConsumed,
+	}
+}
+
+// ResumeParams is the request parameter returned by WithResumeFrom. It is accepted by all the news services.
+type ResumeParams struct {
+	cursor Cursor
+}
+
+func (r ResumeParams) apply(p requestParams, endpoint endpoint, v *paramValidator) {
+	if r.cursor.Endpoint != string(endpoint) {
+		v.error("page", r.cursor.PageToken, fmt.Sprintf("newsdata: cursor was created for endpoint %s, not %s", r.cursor.Endpoint, endpoint))
+		return
+	}
+	for key, value := range r.cursor.Params {
+		p[key] = value
+	}
+	if r.cursor.PageToken != "" {
+		p["page"] = r.cursor.PageToken
+	}
+}
+func (ResumeParams) latestNewsParam()  {}
+func (ResumeParams) newsArchiveParam() {}
+func (ResumeParams) cryptoNewsParam()  {}
+func (ResumeParams) marketNewsParam()  {}
+
+// WithResumeFrom resumes a request from a cursor returned in a Page.
+//
+// The query parameters of the cursor override the ones with the same name set by the query and the parameters
+// placed before it, so the query can be left empty. The other parameters are kept, and added to the resumed request.
+func WithResumeFrom(cursor Cursor) ResumeParams {
+	return ResumeParams{cursor: cursor}
+}
+
+// StreamError is the error sent by Stream when it stops before all the matching articles are sent.
+//
+// Cursor is the position after the last page whose articles were all sent, or the cursor the request was resumed
+// from if no page was entirely sent. The request can be resumed from it with WithResumeFrom: the articles of the
+// page being sent when Stream stopped are sent again. Cursor is nil if the request must be restarted.
+type StreamError struct {
+	Err    error   // Error that stopped the stream
+	Cursor *Cursor // Cursor to resume the request, nil if no page was entirely sent
+}
+
+func (e *StreamError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *StreamError) Unwrap() error {
+	return e.Err
+}
+
+// resumedCursor returns the cursor in params, if any.
+func resumedCursor[P requestParam](params []P) *Cursor {
+	var cursor *Cursor
+	for _, param := range params {
+		if r, ok := any(param).(ResumeParams); ok {
+			cursor = &r.cursor
+		}
+	}
+	return cursor
+}
+
+// resumedArticlesCount returns the number of articles already retrieved according to the cursor in params, if any.
+func resumedArticlesCount[P requestParam](params []P) int {
+	count := 0
+	for _, param := range params {
+		if r, ok := any(param).(ResumeParams); ok {
+			count = r.cursor.ArticlesConsumed
+		}
+	}
+	return count
+}
@@ -0,0 +1,374 @@
+package newsdata
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"iter"
+	"math/bits"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+const (
+	defaultDedupeWindow    = 10000 // Number of articles remembered by default
+	defaultDedupeThreshold = 6     // Default maximum Hamming distance between the SimHashes of near-duplicates
+	minSimHashTokens       = 8     // Minimum number of words for an article to be compared with SimHash
+	minSimHashBands        = 4     // Minimum number of bands of the SimHash index (16 bits each)
+)
+
+// trackingParams are the query parameters removed from links before comparing them.
+var trackingParams = []string{"fbclid", "gclid", "dclid", "msclkid", "mc_cid", "mc_eid", "igshid", "ref", "ref_src", "cmpid", "ocid"}
+
+// Deduper drops the articles already seen, across pages and requests.
+//
+// An article is a duplicate of a previous one if they have the same ID, the same canonical link
+// (ignoring the scheme, "www.", the fragment, tracking parameters and the order of the query parameters),
+// or near-duplicate texts: the Hamming distance between the SimHashes of their title, description and content
+// is at most the threshold set with WithDedupeThreshold.
+//
+// Only the last articles kept are remembered (see WithDedupeWindow), so the memory used is bounded.
+// SimHashes are indexed by band, so an article is only compared with the articles sharing a band of its SimHash.
+// IDs and links can also be remembered in a SeenStore (see WithDedupeStore), e.g. to survive process restarts.
+// A Deduper is safe for concurrent use.
+type Deduper struct {
+	mu        sync.Mutex
+	window    int
+	threshold int
+	entries   []dedupeEntry // Ring buffer of the articles remembered
+	next      int           // Index of the next entry to overwrite once the window is full
+	ids       map[string]struct{}
+	links     map[string]struct{}
+	bands     int                   // Number of bands of the SimHash index
+	simHashes map[simHashBand][]int // Indexes in entries of the SimHashes, by band
+	dropped   int
+	store     SeenStore // Store of the IDs and links, nil to only use the window
+	err       error     // First error of the store
+}
+
+// dedupeEntry is the fingerprint of an article remembered by a Deduper.
+type dedupeEntry struct {
+	id      string
+	link    string
+	simHash uint64
+	hashed  bool // Whether the article has enough words to be compared with SimHash
+}
+
+// simHashBand is a band of a SimHash: a range of its bits, and their value.
+type simHashBand struct {
+	band  int
+	value uint64
+}
+
+// DeduperOption is a functional option for configuring a Deduper.
+type DeduperOption func(*Deduper)
+
+// WithDedupeWindow sets the number of articles remembered by the Deduper. Older articles are forgotten.
+//
+// If no window is provided, the Deduper remembers the last 10000 articles.
+func WithDedupeWindow(articles int) DeduperOption {
+	return func(d *Deduper) {
+		if articles > 0 {
+			d.window = articles
+		}
+	}
+}
+
+// WithDedupeThreshold sets the maximum Hamming distance (out of 64 bits) between the SimHashes of two near-duplicate articles.
+// The higher the threshold, the more different the texts of near-duplicates can be. A negative threshold disables
+// the near-duplicate detection, to compare only IDs and links.
+//
+// If no threshold is provided, the Deduper uses 6, which catches the same story with a few words changed.
+func WithDedupeThreshold(bits int) DeduperOption {
+	return func(d *Deduper) {
+		d.threshold = bits
+	}
+}
+
+// WithDedupeStore also remembers the IDs and canonical links of the articles in store, without window limit.
+// Keys are prefixed with "id/" and "link/". Near-duplicates are only detected within the window.
+//
+// Errors of the store do not stop the Deduper: articles are then considered new, and the first error is returned by Err.
+func WithDedupeStore(store SeenStore) DeduperOption {
+	return func(d *Deduper) {
+		d.store = store
+	}
+}
+
+// NewDeduper creates a new Deduper with the given options.
+func NewDeduper(opts ...DeduperOption) *Deduper {
+	d := &Deduper{
+		window:    defaultDedupeWindow,
+		threshold: defaultDedupeThreshold,
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	// Near-duplicates differ by at most threshold bits: with more bands than that, they share at least one band.
+	d.bands = min(max(minSimHashBands, d.threshold+1), 64)
+	d.Reset()
+	return d
+}
+
+// Reset forgets all the articles seen in the window. The store set with WithDedupeStore is left unchanged.
+func (d *Deduper) Reset() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.entries = make([]dedupeEntry, 0, min(d.window, 1024))
+	d.next = 0
+	d.ids = make(map[string]struct{})
+	d.links = make(map[string]struct{})
+	d.simHashes = make(map[simHashBand][]int)
+	d.dropped = 0
+	d.err = nil
+}
+
+// Err returns the first error of the store set with WithDedupeStore, if any.
+func (d *Deduper) Err() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.err
+}
+
+// Dropped returns the number of duplicates found since the creation of the Deduper or the last Reset.
+func (d *Deduper) Dropped() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.dropped
+}
+
+// IsDuplicate reports whether article is a duplicate of an article already seen.
+// If it is not, the article is remembered.
+func (d *Deduper) IsDuplicate(article *NewsArticle) bool {
+	entry := dedupeEntry{
+		id:   article.Id,
+		link: canonicalLink(article.Link),
+	}
+	if d.threshold >= 0 {
+		entry.simHash, entry.hashed = simHash(article.Title, article.Description, article.Content)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.isDuplicate(entry) || d.isStored(entry) {
+		d.dropped++
+		return true
+	}
+	d.remember(entry)
+	return false
+}
+
+// storeKeys returns the keys of an entry in the store.
+func (e dedupeEntry) storeKeys() []string {
+	var keys []string
+	if e.id != "" {
+		keys = append(keys, "id/"+e.id)
+	}
+	if e.link != "" {
+		keys = append(keys, "link/"+e.link)
+	}
+	return keys
+}
+
+// isStored reports whether the ID or link of entry is in the store. It must be called with mu held.
+func (d *Deduper) isStored(entry dedupeEntry) bool {
+	if d.store == nil {
+		return false
+	}
+	for _, key := range entry.storeKeys() {
+		seen, err := d.store.Has(key)
+		if err != nil {
+			d.setErr(err)
+			return false
+		}
+		if seen {
+			return true
+		}
+	}
+	return false
+}
+
+// setErr records the first error of the store. It must be called with mu held.
+func (d *Deduper) setErr(err error) {
+	if d.err == nil {
+		d.err = fmt.Errorf("newsdata: Deduper - store error: %w", err)
+	}
+}
+
+// isDuplicate reports whether entry matches an entry remembered. It must be called with mu held.
+func (d *Deduper) isDuplicate(entry dedupeEntry) bool {
+	if _, ok := d.ids[entry.id]; ok && entry.id != "" {
+		return true
+	}
+	if _, ok := d.links[entry.link]; ok && entry.link != "" {
+		return true
+	}
+	if !entry.hashed {
+		return false
+	}
+	for _, band := range d.simHashBands(entry.simHash) {
+		for _, i := range d.simHashes[band] {
+			if bits.OnesCount64(d.entries[i].simHash^entry.simHash) <= d.threshold {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// simHashBands splits a SimHash in bands.
+func (d *Deduper) simHashBands(hash uint64) []simHashBand {
+	bands := make([]simHashBand, d.bands)
+	for i := range bands {
+		start, end := i*64/d.bands, (i+1)*64/d.bands
+		bands[i] = simHashBand{band: i, value: (hash >> start) & (1<<(end-start) - 1)}
+	}
+	return bands
+}
+
+// remember adds entry to the window, forgetting the oldest entry if the window is full. It must be called with mu held.
+func (d *Deduper) remember(entry dedupeEntry) {
+	index := len(d.entries)
+	if index < d.window {
+		d.entries = append(d.entries, entry)
+	} else {
+		index = d.next
+		oldest := d.entries[index]
+		delete(d.ids, oldest.id)
+		delete(d.links, oldest.link)
+		if oldest.hashed {
+			for _, band := range d.simHashBands(oldest.simHash) {
+				d.simHashes[band] = slices.DeleteFunc(d.simHashes[band], func(i int) bool { return i == index })
+				if len(d.simHashes[band]) == 0 {
+					delete(d.simHashes, band)
+				}
+			}
+		}
+		d.entries[index] = entry
+		d.next = (d.next + 1) % d.window
+	}
+	if entry.hashed {
+		for _, band := range d.simHashBands(entry.simHash) {
+			d.simHashes[band] = append(d.simHashes[band], index)
+		}
+	}
+	if entry.id != "" {
+		d.ids[entry.id] = struct{}{}
+	}
+	if entry.link != "" {
+		d.links[entry.link] = struct{}{}
+	}
+	if keys := entry.storeKeys(); d.store != nil && len(keys) > 0 {
+		if err := d.store.Add(keys...); err != nil {
+			d.setErr(err)
+		}
+	}
+}
+
+// All returns an iterator over the articles of seq that are not duplicates.
+//
+//	deduper := newsdata.NewDeduper()
+//	for article, err := range deduper.All(client.NewsArchive.All(ctx, "bitcoin")) {
+//		...
+//	}
+func (d *Deduper) All(seq iter.Seq2[*NewsArticle, error]) iter.Seq2[*NewsArticle, error] {
+	return func(yield func(*NewsArticle, error) bool) {
+		for article, err := range seq {
+			if err == nil && d.IsDuplicate(article) {
+				continue
+			}
+			if !yield(article, err) {
+				return
+			}
+		}
+	}
+}
+
+// Stream wraps the channels returned by a Stream method, dropping the duplicates.
+//
+// Errors are forwarded once the articles channel is closed.
+//
+//	articles, errChan := client.NewsArchive.Stream(ctx, "bitcoin")
+//	articles, errChan = deduper.Stream(ctx, articles, errChan)
+func (d *Deduper) Stream(ctx context.Context, articles <-chan *NewsArticle, errs <-chan error) (<-chan *NewsArticle, <-chan error) {
+	out := make(chan *NewsArticle)
+	errChan := make(chan error, 1)
+
+	go func() {
+		defer close(out)
+		defer close(errChan)
+		for article := range articles {
+			if d.IsDuplicate(article) {
+				continue
+			}
+			select {
+			case out <- article:
+			case <-ctx.Done():
+				errChan <- fmt.Errorf("newsdata: Deduper.Stream - context done: %w", ctx.Err())
+				return
+			}
+		}
+		if err, ok := <-errs; ok {
+			errChan <- err
+		}
+	}()
+	return out, errChan
+}
+
+// canonicalLink returns the link without the scheme, "www.", the fragment, the tracking parameters
+// and the trailing slash, and with the query parameters sorted.
+func canonicalLink(link string) string {
+	u, err := url.Parse(strings.TrimSpace(link))
+	if err != nil || u.Host == "" {
+		return strings.ToLower(strings.TrimSpace(link))
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	query := u.Query()
+	for param := range query {
+		if strings.HasPrefix(strings.ToLower(param), "utm_") || slices.Contains(trackingParams, strings.ToLower(param)) {
+			query.Del(param)
+		}
+	}
+	canonical := host + strings.TrimSuffix(u.EscapedPath(), "/")
+	if len(query) > 0 {
+		canonical += "?" + query.Encode() // Encode sorts the parameters by key
+	}
+	return canonical
+}
+
+// simHash returns the 64-bit SimHash of the words of texts, and whether there are enough words
+// for the SimHash to be meaningful.
+func simHash(texts ...string) (uint64, bool) {
+	var weights [64]int
+	words := 0
+	for _, text := range texts {
+		for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+		}) {
+			h := fnv.New64a()
+			h.Write([]byte(word))
+			sum := h.Sum64()
+			for bit := range weights {
+				if sum&(1<<bit) != 0 {
+					weights[bit]++
+				} else {
+					weights[bit]--
+				}
+			}
+			words++
+		}
+	}
+	if words < minSimHashTokens {
+		return 0, false
+	}
+	var hash uint64
+	for bit, weight := range weights {
+		if weight > 0 {
+			hash |= 1 << bit
+		}
+	}
+	return hash, true
+}
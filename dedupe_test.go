@@ -0,0 +1,146 @@
+package newsdata
+
+import (
+	"context"
+	"fmt"
+	"math/bits"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestCanonicalLink(t *testing.T) {
+	tests := []struct {
+		a, b string
+	}{
+		{"https://www.example.com/news/1/", "http://example.com/news/1"},
+		{"https://example.com/news/1?utm_source=rss&utm_medium=feed", "https://example.com/news/1"},
+		{"https://example.com/news/1?b=2&a=1#comments", "https://EXAMPLE.com/news/1?a=1&b=2&fbclid=abc"},
+	}
+	for _, test := range tests {
+		if canonicalLink(test.a) != canonicalLink(test.b) {
+			t.Fatalf("Canonical links of %s and %s should be equal: %s, %s", test.a, test.b, canonicalLink(test.a), canonicalLink(test.b))
+		}
+	}
+	if canonicalLink("https://example.com/news/1?id=1") == canonicalLink("https://example.com/news/1?id=2") {
+		t.Fatalf("Canonical links with different query parameters should be different")
+	}
+}
+
+func TestDeduper(t *testing.T) {
+	wire := "Oil prices rose sharply on Tuesday after producers agreed to extend output cuts until the end of next year, analysts said"
+	articles := []*NewsArticle{
+		{Id: "1", Link: "https://example.com/oil", Title: "Oil prices rise", Content: wire},
+		{Id: "1", Link: "https://mirror.example.net/oil", Title: "Oil prices rise"},                               // same ID
+		{Id: "2", Link: "https://www.example.com/oil/?utm_source=rss", Title: "Oil prices rise again"},            // same link
+		{Id: "3", Link: "https://other.example.org/oil", Title: "Oil prices rise", Content: wire + "."},           // same text
+		{Id: "4", Link: "https://other.example.org/oil-2", Title: "Oil prices rise:", Content: "Update: " + wire}, // near-duplicate text
+		{Id: "5", Link: "https://example.com/football", Title: "Club wins the cup", Content: "The home side won the final in extra time after a goalless draw in front of a record crowd"},
+		{Id: "6", Link: "https://example.com/short", Title: "Oil prices rise"}, // too short to be compared by text
+	}
+	deduper := NewDeduper()
+	var kept []string
+	for _, article := range articles {
+		if !deduper.IsDuplicate(article) {
+			kept = append(kept, article.Id)
+		}
+	}
+	if !slices.Equal(kept, []string{"1", "5", "6"}) {
+		t.Fatalf("Kept articles should be 1, 5 and 6, got %v", kept)
+	}
+	if deduper.Dropped() != 4 {
+		t.Fatalf("Dropped articles should be 4, got %d", deduper.Dropped())
+	}
+
+	deduper = NewDeduper(WithDedupeThreshold(-1))
+	kept = nil
+	for _, article := range articles {
+		if !deduper.IsDuplicate(article) {
+			kept = append(kept, article.Id)
+		}
+	}
+	if !slices.Equal(kept, []string{"1", "3", "4", "5", "6"}) {
+		t.Fatalf("Kept articles without near-duplicate detection should be 1, 3, 4, 5 and 6, got %v", kept)
+	}
+}
+
+func TestDeduperWindow(t *testing.T) {
+	deduper := NewDeduper(WithDedupeWindow(2))
+	article := func(i int) *NewsArticle {
+		return &NewsArticle{Id: fmt.Sprint(i), Link: fmt.Sprintf("https://example.com/%d", i)}
+	}
+	for i := range 3 {
+		if deduper.IsDuplicate(article(i)) {
+			t.Fatalf("Article %d should not be a duplicate", i)
+		}
+	}
+	if !deduper.IsDuplicate(article(2)) || !deduper.IsDuplicate(article(1)) {
+		t.Fatalf("Articles in the window should be duplicates")
+	}
+	if deduper.IsDuplicate(article(0)) {
+		t.Fatalf("Article out of the window should be forgotten")
+	}
+	if len(deduper.ids) != 2 || len(deduper.links) != 2 {
+		t.Fatalf("Deduper should remember 2 articles, got %d IDs and %d links", len(deduper.ids), len(deduper.links))
+	}
+}
+
+func TestDeduperStream(t *testing.T) {
+	in := make(chan *NewsArticle)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(in)
+		defer close(errs)
+		for _, id := range []string{"1", "2", "1", "3", "2"} {
+			in <- &NewsArticle{Id: id}
+		}
+		errs <- fmt.Errorf("stream failed")
+	}()
+
+	out, errChan := NewDeduper().Stream(context.Background(), in, errs)
+	var ids []string
+	for article := range out {
+		ids = append(ids, article.Id)
+	}
+	if !slices.Equal(ids, []string{"1", "2", "3"}) {
+		t.Fatalf("Streamed articles should be 1, 2 and 3, got %v", ids)
+	}
+	if err := <-errChan; err == nil || err.Error() != "stream failed" {
+		t.Fatalf("Error should be forwarded, got %v", err)
+	}
+}
+
+func TestDeduperSimHashIndex(t *testing.T) {
+	// The index finds the same near-duplicates as comparing with every entry of the window.
+	rng := rand.New(rand.NewPCG(1, 2))
+	for _, threshold := range []int{3, 6, 10} {
+		deduper := NewDeduper(WithDedupeWindow(500), WithDedupeThreshold(threshold))
+		for i := range 5000 {
+			hash := rng.Uint64()
+			if i%2 == 1 {
+				// A near-duplicate of a recent entry.
+				hash = deduper.entries[rng.IntN(len(deduper.entries))].simHash
+				for range rng.IntN(threshold + 4) {
+					hash ^= 1 << rng.IntN(64)
+				}
+			}
+			entry := dedupeEntry{id: fmt.Sprint(i), simHash: hash, hashed: true}
+			want := slices.ContainsFunc(deduper.entries, func(e dedupeEntry) bool {
+				return bits.OnesCount64(e.simHash^hash) <= threshold
+			})
+			if got := deduper.isDuplicate(entry); got != want {
+				t.Fatalf("Invalid near-duplicate detection with threshold %d: %t - should be %t", threshold, got, want)
+			}
+			if !want {
+				deduper.remember(entry)
+			}
+		}
+		indexed := 0
+		for _, indexes := range deduper.simHashes {
+			indexed += len(indexes)
+		}
+		if indexed != deduper.bands*len(deduper.entries) {
+			t.Fatalf("Forgotten entries should be removed from the index: %d indexed - should be %d", indexed, deduper.bands*len(deduper.entries))
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package newsdata
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Sentinel errors returned (wrapped in an *APIError) when the NewsData API answers with an error.
+//
+// Use errors.Is to branch on them, and errors.As to access the underlying *APIError.
+var (
+	ErrUnauthorized   = errors.New("newsdata: unauthorized")
+	ErrRateLimited    = errors.New("newsdata: rate limited")
+	ErrQuotaExceeded  = errors.New("newsdata: quota exceeded")
+	ErrPlanRestricted = errors.New("newsdata: feature not available in current plan")
+	ErrInvalidParam   = errors.New("newsdata: invalid parameter")
+)
+
+// ErrArticleNotFound is matched by the *NotFoundError returned by GetByIDs when some articles were not found.
+var ErrArticleNotFound = errors.New("newsdata: article not found")
+
+// ErrCreditBudgetExceeded is returned when the credit budget set with WithCreditBudget is spent.
+var ErrCreditBudgetExceeded = errors.New("newsdata: credit budget exceeded")
+
+// APIError represents an error response returned by the NewsData API.
+//
+// See https://newsdata.io/documentation/#http_response for the list of status codes.
+type APIError struct {
+	StatusCode int               // HTTP status code of the response
+	Code       string            // Error code returned by the API (e.g. "Unauthorized")
+	Message    string            // Error message returned by the API
+	Endpoint   string            // Endpoint that was called (e.g. "latest")
+	Params     map[string]string // Query parameters of the request, without sensitive values
+	RetryAfter time.Duration     // Delay requested by the API before retrying (from the Retry-After header), if any
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = http.StatusText(e.StatusCode)
+	}
+	if e.Code != "" {
+		return fmt.Sprintf("newsdata: %s - status: %d, code: %s, endpoint: %s", msg, e.StatusCode, e.Code, e.Endpoint)
+	}
+	return fmt.Sprintf("newsdata: %s - status: %d, endpoint: %s", msg, e.StatusCode, e.Endpoint)
+}
+
+// Is reports whether the error matches one of the sentinel errors.
+// It allows errors.Is(err, ErrRateLimited) and the like to work on an *APIError.
+func (e *APIError) Is(target error) bool {
+	return target != nil && e.sentinel() == target
+}
+
+// sentinel maps the API error to its sentinel error based on its status code and API error code.
+// It returns nil if the error does not match any sentinel.
+func (e *APIError) sentinel() error {
+	code := strings.ToLower(e.Code)
+	switch {
+	case strings.Contains(code, "quota") || strings.Contains(code, "credit") || strings.Contains(code, "apilimit"):
+		return ErrQuotaExceeded
+	case strings.Contains(code, "ratelimit") || strings.Contains(code, "toomanyrequests"):
+		return ErrRateLimited
+	case strings.Contains(code, "unauthorized") || strings.Contains(code, "apikey"):
+		return ErrUnauthorized
+	case strings.Contains(code, "plan") || strings.Contains(code, "access"):
+		return ErrPlanRestricted
+	case strings.Contains(code, "unsupported") || strings.Contains(code, "invalid") || strings.Contains(code, "parameter"):
+		return ErrInvalidParam
+	}
+	switch e.StatusCode {
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusForbidden:
+		return ErrPlanRestricted
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	case http.StatusBadRequest, http.StatusConflict, http.StatusUnsupportedMediaType, http.StatusUnprocessableEntity:
+		return ErrInvalidParam
+	}
+	return nil
+}
+
+// sanitizeParams returns a copy of the request parameters without sensitive values.
+func sanitizeParams(params requestParams) map[string]string {
+	sanitized := make(map[string]string, len(params))
+	for key, value := range params {
+		if strings.EqualFold(key, "apikey") {
+			continue
+		}
+		sanitized[key] = value
+	}
+	return sanitized
+}
+
+// ParamIssue describes an invalid request parameter value.
+type ParamIssue struct {
+	Param  string // Name of the query parameter (e.g. "country")
+	Value  string // Invalid value
+	Reason string // Why the value is invalid
+}
+
+// ParamError is returned in strict mode (see WithStrictParams) when some request parameters are invalid.
+//
+// It lists every invalid value, and matches ErrInvalidParam with errors.Is.
+type ParamError struct {
+	Endpoint string       // Endpoint the parameters were built for (e.g. "latest")
+	Issues   []ParamIssue // Invalid parameter values
+}
+
+// Error implements the error interface.
+func (e *ParamError) Error() string {
+	issues := make([]string, 0, len(e.Issues))
+	for _, issue := range e.Issues {
+		issues = append(issues, fmt.Sprintf("%s=%q: %s", issue.Param, issue.Value, issue.Reason))
+	}
+	return fmt.Sprintf("newsdata: invalid parameters for endpoint %s: %s", e.Endpoint, strings.Join(issues, "; "))
+}
+
+// Is reports whether the target is ErrInvalidParam.
+func (e *ParamError) Is(target error) bool {
+	return target == ErrInvalidParam
+}
+
+// NotFoundError is returned by GetByIDs when some articles were not found.
+//
+// It matches ErrArticleNotFound with errors.Is.
+type NotFoundError struct {
+	IDs []string // IDs of the articles that were not found, in the order they were requested
+}
+
+// Error implements the error interface.
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("newsdata: %d article(s) not found: %s", len(e.IDs), strings.Join(e.IDs, ", "))
+}
+
+// Is reports whether the target is ErrArticleNotFound.
+func (e *NotFoundError) Is(target error) bool {
+	return target == ErrArticleNotFound
+}
@@ -0,0 +1,53 @@
+package newsdata
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"status":"error","results":{"message":"Rate limit exceeded","code":"RateLimitExceeded"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test"), WithBaseURL(server.URL))
+	_, err := client.LatestNews.Get(context.Background(), "ai", 10)
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("Error should be ErrRateLimited: %v", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Error should be an *APIError: %v", err)
+	}
+	if apiErr.StatusCode != http.StatusTooManyRequests || apiErr.Code != "RateLimitExceeded" || apiErr.Endpoint != "latest" {
+		t.Fatalf("Invalid APIError: %+v", apiErr)
+	}
+	if apiErr.Params["q"] != "ai" {
+		t.Fatalf("Invalid APIError params: %v", apiErr.Params)
+	}
+}
+
+func TestAPIErrorSentinels(t *testing.T) {
+	tests := []struct {
+		err  *APIError
+		want error
+	}{
+		{&APIError{StatusCode: http.StatusUnauthorized}, ErrUnauthorized},
+		{&APIError{StatusCode: http.StatusForbidden}, ErrPlanRestricted},
+		{&APIError{StatusCode: http.StatusUnprocessableEntity, Code: "UnsupportedFilter"}, ErrInvalidParam},
+		{&APIError{StatusCode: http.StatusTooManyRequests, Code: "ApiLimitExceeded"}, ErrQuotaExceeded},
+	}
+	for _, test := range tests {
+		if !errors.Is(test.err, test.want) {
+			t.Errorf("%v should match %v", test.err, test.want)
+		}
+	}
+	if errors.Is(&APIError{StatusCode: http.StatusInternalServerError}, ErrInvalidParam) {
+		t.Errorf("500 should not match ErrInvalidParam")
+	}
+}
@@ -0,0 +1,197 @@
+package export
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/sicamois/newsdata"
+)
+
+// column is a column of an export: how to format a field of an article, and how to parse it back.
+type column struct {
+	name     string
+	jsonName string // Name of the field in the JSON representation of an article
+	format   func(a *newsdata.NewsArticle) (string, error)
+	parse    func(a *newsdata.NewsArticle, value string) error
+}
+
+// columns are the columns of an export, in their default order.
+//
+// List fields are formatted as CSV records (see newsdata.Tags), and the sentiment statistics are flattened
+// in one column per sentiment.
+var columns = []column{
+	stringColumn("article_id", func(a *newsdata.NewsArticle) *string { return &a.Id }),
+	stringColumn("title", func(a *newsdata.NewsArticle) *string { return &a.Title }),
+	stringColumn("link", func(a *newsdata.NewsArticle) *string { return &a.Link }),
+	tagsColumn("keywords", func(a *newsdata.NewsArticle) *newsdata.Tags { return &a.Keywords }),
+	tagsColumn("creator", func(a *newsdata.NewsArticle) *newsdata.Tags { return &a.Creator }),
+	stringColumn("video_url", func(a *newsdata.NewsArticle) *string { return &a.VideoURL }),
+	stringColumn("description", func(a *newsdata.NewsArticle) *string { return &a.Description }),
+	stringColumn("content", func(a *newsdata.NewsArticle) *string { return &a.Content }),
+	{
+		name:     "pubDate",
+		jsonName: "pubDate",
+		format: func(a *newsdata.NewsArticle) (string, error) {
+			text, err := a.PubDate.MarshalText()
+			return string(text), err
+		},
+		parse: func(a *newsdata.NewsArticle, value string) error {
+			return a.PubDate.UnmarshalText([]byte(value))
+		},
+	},
+	stringColumn("pubDateTZ", func(a *newsdata.NewsArticle) *string { return &a.PubDateTZ }),
+	stringColumn("image_url", func(a *newsdata.NewsArticle) *string { return &a.ImageURL }),
+	stringColumn("source_id", func(a *newsdata.NewsArticle) *string { return &a.SourceId }),
+	{
+		name:     "source_priority",
+		jsonName: "source_priority",
+		format: func(a *newsdata.NewsArticle) (string, error) {
+			return strconv.Itoa(a.SourcePriority), nil
+		},
+		parse: func(a *newsdata.NewsArticle, value string) error {
+			if value == "" {
+				a.SourcePriority = 0
+				return nil
+			}
+			priority, err := strconv.Atoi(value)
+			a.SourcePriority = priority
+			return err
+		},
+	},
+	stringColumn("source_name", func(a *newsdata.NewsArticle) *string { return &a.SourceName }),
+	stringColumn("source_url", func(a *newsdata.NewsArticle) *string { return &a.SourceURL }),
+	stringColumn("source_icon", func(a *newsdata.NewsArticle) *string { return &a.SourceIconURL }),
+	stringColumn("language", func(a *newsdata.NewsArticle) *string { return &a.Language }),
+	listColumn("country", func(a *newsdata.NewsArticle) *[]string { return &a.Countries }),
+	listColumn("category", func(a *newsdata.NewsArticle) *[]string { return &a.Categories }),
+	tagsColumn("ai_tag", func(a *newsdata.NewsArticle) *newsdata.Tags { return &a.AiTags }),
+	stringColumn("sentiment", func(a *newsdata.NewsArticle) *string { return &a.Sentiment }),
+	sentimentColumn("sentiment_positive", func(s *newsdata.SentimentStats) *float64 { return &s.Positive }),
+	sentimentColumn("sentiment_neutral", func(s *newsdata.SentimentStats) *float64 { return &s.Neutral }),
+	sentimentColumn("sentiment_negative", func(s *newsdata.SentimentStats) *float64 { return &s.Negative }),
+	tagsColumn("ai_region", func(a *newsdata.NewsArticle) *newsdata.Tags { return &a.AiRegions }),
+	tagsColumn("coin", func(a *newsdata.NewsArticle) *newsdata.Tags { return &a.Coin }),
+	tagsColumn("symbol", func(a *newsdata.NewsArticle) *newsdata.Tags { return &a.Symbols }),
+	tagsColumn("ai_org", func(a *newsdata.NewsArticle) *newsdata.Tags { return &a.Organizations }),
+	{
+		name:     "duplicate",
+		jsonName: "duplicate",
+		format: func(a *newsdata.NewsArticle) (string, error) {
+			return strconv.FormatBool(a.Duplicate), nil
+		},
+		parse: func(a *newsdata.NewsArticle, value string) error {
+			if value == "" {
+				a.Duplicate = false
+				return nil
+			}
+			duplicate, err := strconv.ParseBool(value)
+			a.Duplicate = duplicate
+			return err
+		},
+	},
+}
+
+// Columns returns the names of the columns that can be exported, in their default order.
+//
+// Columns are named as the fields of the API responses, except the sentiment statistics, which are
+// flattened in the sentiment_positive, sentiment_neutral and sentiment_negative columns.
+func Columns() []string {
+	names := make([]string, len(columns))
+	for i, c := range columns {
+		names[i] = c.name
+	}
+	return names
+}
+
+// lookupColumns returns the columns with the given names, or all the columns if names is empty.
+func lookupColumns(names []string) ([]column, error) {
+	if len(names) == 0 {
+		return columns, nil
+	}
+	selected := make([]column, len(names))
+	for i, name := range names {
+		found := false
+		for _, c := range columns {
+			if c.name == name {
+				selected[i] = c
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("export: unknown column %q", name)
+		}
+	}
+	return selected, nil
+}
+
+func stringColumn(name string, field func(a *newsdata.NewsArticle) *string) column {
+	return column{
+		name:     name,
+		jsonName: name,
+		format: func(a *newsdata.NewsArticle) (string, error) {
+			return *field(a), nil
+		},
+		parse: func(a *newsdata.NewsArticle, value string) error {
+			*field(a) = value
+			return nil
+		},
+	}
+}
+
+func tagsColumn(name string, field func(a *newsdata.NewsArticle) *newsdata.Tags) column {
+	return column{
+		name:     name,
+		jsonName: name,
+		format: func(a *newsdata.NewsArticle) (string, error) {
+			text, err := field(a).MarshalText()
+			return string(text), err
+		},
+		parse: func(a *newsdata.NewsArticle, value string) error {
+			return field(a).UnmarshalText([]byte(value))
+		},
+	}
+}
+
+// listColumn is a column of a []string field, formatted as Tags.
+func listColumn(name string, field func(a *newsdata.NewsArticle) *[]string) column {
+	return column{
+		name:     name,
+		jsonName: name,
+		format: func(a *newsdata.NewsArticle) (string, error) {
+			text, err := newsdata.Tags(*field(a)).MarshalText()
+			return string(text), err
+		},
+		parse: func(a *newsdata.NewsArticle, value string) error {
+			var tags newsdata.Tags
+			if err := tags.UnmarshalText([]byte(value)); err != nil {
+				return err
+			}
+			*field(a) = tags
+			return nil
+		},
+	}
+}
+
+// sentimentColumn is a column of one of the sentiment statistics. It is empty when no statistics are available.
+func sentimentColumn(name string, score func(s *newsdata.SentimentStats) *float64) column {
+	return column{
+		name:     name,
+		jsonName: "sentiment_stats",
+		format: func(a *newsdata.NewsArticle) (string, error) {
+			if a.SentimentStats == (newsdata.SentimentStats{}) {
+				return "", nil
+			}
+			return strconv.FormatFloat(*score(&a.SentimentStats), 'g', -1, 64), nil
+		},
+		parse: func(a *newsdata.NewsArticle, value string) error {
+			if value == "" {
+				*score(&a.SentimentStats) = 0
+				return nil
+			}
+			f, err := strconv.ParseFloat(value, 64)
+			*score(&a.SentimentStats) = f
+			return err
+		},
+	}
+}
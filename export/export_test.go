@@ -0,0 +1,154 @@
+package export_test
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sicamois/newsdata"
+	"github.com/sicamois/newsdata/export"
+	"github.com/sicamois/newsdata/newsdatatest"
+)
+
+// testArticles returns sample articles, and an article with values that need escaping in every format.
+func testArticles() []newsdata.NewsArticle {
+	articles := newsdatatest.SampleArticles(5)
+	paris, _ := time.LoadLocation("Europe/Paris")
+	articles = append(articles, newsdata.NewsArticle{
+		Id:             "tricky",
+		Title:          "Tabs\tand \"quotes\", commas",
+		Content:        "Line 1\nLine 2\r\nC:\\path\\to\\file",
+		PubDate:        newsdata.DateTime{Time: time.Date(2025, 1, 9, 14, 30, 0, 0, paris)},
+		PubDateTZ:      "Europe/Paris",
+		SourcePriority: 42,
+		Countries:      []string{"united states of america", "france"},
+		Categories:     []string{"top"},
+		AiTags:         newsdata.Tags{"santa clara,california", "technology"},
+		SentimentStats: newsdata.SentimentStats{Positive: 91.27, Neutral: 8.1, Negative: 0.63},
+		Duplicate:      true,
+	})
+	return articles
+}
+
+func TestRoundTrip(t *testing.T) {
+	articles := testArticles()
+	for _, format := range []export.Format{export.JSONL, export.CSV, export.TSV} {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			w, err := export.NewWriter(&buf, format)
+			if err != nil {
+				t.Fatalf("Error creating writer: %v", err)
+			}
+			for i := range articles {
+				if err := w.Write(&articles[i]); err != nil {
+					t.Fatalf("Error writing article: %v", err)
+				}
+			}
+			if err := w.Flush(); err != nil {
+				t.Fatalf("Error flushing writer: %v", err)
+			}
+			if lines := strings.Count(buf.String(), "\n"); format == export.TSV && lines != len(articles)+1 {
+				t.Fatalf("TSV should have one line per article and a header, got %d lines", lines)
+			}
+
+			r, err := export.NewReader(&buf, format)
+			if err != nil {
+				t.Fatalf("Error creating reader: %v", err)
+			}
+			i := 0
+			for article, err := range r.All() {
+				if err != nil {
+					t.Fatalf("Error reading article %d: %v", i, err)
+				}
+				want := articles[i]
+				if !article.PubDate.Equal(want.PubDate.Time) {
+					t.Fatalf("Invalid publication date of article %d: %v - should be %v", i, article.PubDate, want.PubDate)
+				}
+				article.PubDate, want.PubDate = newsdata.DateTime{}, newsdata.DateTime{}
+				if format == export.CSV {
+					want.Content = strings.ReplaceAll(want.Content, "\r\n", "\n") // Normalized by encoding/csv
+				}
+				if !reflect.DeepEqual(*article, want) {
+					t.Fatalf("Invalid article %d:\n%+v\nshould be\n%+v", i, *article, want)
+				}
+				i++
+			}
+			if i != len(articles) {
+				t.Fatalf("Invalid number of articles read: %d - should be %d", i, len(articles))
+			}
+		})
+	}
+}
+
+func TestColumns(t *testing.T) {
+	articles := testArticles()
+	tricky := &articles[len(articles)-1]
+
+	var buf bytes.Buffer
+	w, _ := export.NewWriter(&buf, export.CSV, export.WithColumns("article_id", "country", "ai_tag", "sentiment_positive", "sentiment_negative"))
+	w.Write(tricky)
+	w.Flush()
+	want := "article_id,country,ai_tag,sentiment_positive,sentiment_negative\n" +
+		`tricky,"united states of america,france","""santa clara,california"",technology",91.27,0.63` + "\n"
+	if buf.String() != want {
+		t.Fatalf("Invalid CSV:\n%s\nshould be\n%s", buf.String(), want)
+	}
+
+	r, _ := export.NewReader(&buf, export.CSV)
+	article, err := r.Read()
+	if err != nil {
+		t.Fatalf("Error reading article: %v", err)
+	}
+	if article.Title != "" || !reflect.DeepEqual(article.AiTags, tricky.AiTags) || article.SentimentStats.Negative != 0.63 {
+		t.Fatalf("Invalid article: %+v", article)
+	}
+
+	buf.Reset()
+	w, _ = export.NewWriter(&buf, export.JSONL, export.WithColumns("article_id", "sentiment_positive", "sentiment_neutral"))
+	w.Write(tricky)
+	if want := `{"article_id":"tricky","sentiment_stats":{"positive":91.27,"neutral":8.1,"negative":0.63}}` + "\n"; buf.String() != want {
+		t.Fatalf("Invalid JSON line: %s - should be %s", buf.String(), want)
+	}
+
+	if _, err := export.NewWriter(&buf, export.CSV, export.WithColumns("unknown")); err == nil {
+		t.Fatalf("Unknown column should fail")
+	}
+	if _, err := export.NewWriter(&buf, "xml"); err == nil {
+		t.Fatalf("Unknown format should fail")
+	}
+	if len(export.Columns()) != 29 {
+		t.Fatalf("Invalid number of columns: %d", len(export.Columns()))
+	}
+}
+
+func TestWriteStream(t *testing.T) {
+	server := newsdatatest.NewServer()
+	defer server.Close()
+	server.AddArticles(newsdatatest.EndpointLatest, newsdatatest.SampleArticles(25)...)
+	client := server.NewClient()
+
+	var buf bytes.Buffer
+	w, _ := export.NewWriter(&buf, export.TSV, export.WithColumns("article_id", "title"))
+	articles, errChan := client.LatestNews.Stream(context.Background(), "")
+	count, err := w.WriteStream(articles, errChan)
+	if err != nil {
+		t.Fatalf("Error writing stream: %v", err)
+	}
+	if count != 25 || strings.Count(buf.String(), "\n") != 26 {
+		t.Fatalf("Invalid number of articles written: %d", count)
+	}
+
+	server.SetAPIKey("another-key")
+	buf.Reset()
+	w, _ = export.NewWriter(&buf, export.CSV)
+	articles, errChan = client.LatestNews.Stream(context.Background(), "")
+	if _, err := w.WriteStream(articles, errChan); err == nil {
+		t.Fatalf("Stream error should be returned")
+	}
+	if !strings.HasPrefix(buf.String(), "article_id,title,link,") {
+		t.Fatalf("Header should be written: %s", buf.String())
+	}
+}
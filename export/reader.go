@@ -0,0 +1,174 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"strings"
+
+	"github.com/sicamois/newsdata"
+)
+
+// maxLineSize is the maximum size of a line of a JSONL or TSV file read by a Reader.
+const maxLineSize = 16 * 1024 * 1024
+
+// Reader reads the articles of a file written by a Writer.
+//
+// Columns missing from a CSV or TSV file are left to their zero value. A Reader is not safe for concurrent use.
+type Reader struct {
+	format  Format
+	scanner *bufio.Scanner // Lines of a JSONL or TSV file
+	csv     *csv.Reader
+	columns []column // Columns of a CSV or TSV file, nil until the header is read
+	line    int
+}
+
+// NewReader creates a Reader reading articles in format from r.
+func NewReader(r io.Reader, format Format) (*Reader, error) {
+	er := &Reader{format: format}
+	switch format {
+	case JSONL, TSV:
+		er.scanner = bufio.NewScanner(r)
+		er.scanner.Buffer(nil, maxLineSize)
+	case CSV:
+		er.csv = csv.NewReader(r)
+		er.csv.ReuseRecord = true
+	default:
+		return nil, fmt.Errorf("export: unknown format %q", format)
+	}
+	return er, nil
+}
+
+// Read reads the next article. It returns io.EOF when there are no more articles.
+func (r *Reader) Read() (*newsdata.NewsArticle, error) {
+	if r.format == JSONL {
+		line, err := r.readLine()
+		for err == nil && strings.TrimSpace(line) == "" {
+			line, err = r.readLine()
+		}
+		if err != nil {
+			return nil, err
+		}
+		var article newsdata.NewsArticle
+		if err := json.Unmarshal([]byte(line), &article); err != nil {
+			return nil, fmt.Errorf("export: Read - error unmarshalling article - line: %d: %w", r.line, err)
+		}
+		return &article, nil
+	}
+
+	if r.columns == nil {
+		header, err := r.readRecord()
+		if err != nil {
+			return nil, err
+		}
+		columns, err := lookupColumns(header)
+		if err != nil {
+			return nil, fmt.Errorf("export: Read - invalid header: %w", err)
+		}
+		r.columns = columns
+	}
+	record, err := r.readRecord()
+	if err != nil {
+		return nil, err
+	}
+	if len(record) != len(r.columns) {
+		return nil, fmt.Errorf("export: Read - invalid number of fields - line: %d, fields: %d, expected: %d", r.line, len(record), len(r.columns))
+	}
+	var article newsdata.NewsArticle
+	for i, c := range r.columns {
+		if err := c.parse(&article, record[i]); err != nil {
+			return nil, fmt.Errorf("export: Read - error parsing column %s - line: %d: %w", c.name, r.line, err)
+		}
+	}
+	return &article, nil
+}
+
+// All returns an iterator over the remaining articles. An error is yielded at most once, as the last value.
+func (r *Reader) All() iter.Seq2[*newsdata.NewsArticle, error] {
+	return func(yield func(*newsdata.NewsArticle, error) bool) {
+		for {
+			article, err := r.Read()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if !yield(article, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// readLine reads the next line of a JSONL or TSV file.
+func (r *Reader) readLine() (string, error) {
+	if !r.scanner.Scan() {
+		if err := r.scanner.Err(); err != nil {
+			return "", fmt.Errorf("export: Read - error reading line %d: %w", r.line+1, err)
+		}
+		return "", io.EOF
+	}
+	r.line++
+	return r.scanner.Text(), nil
+}
+
+// readRecord reads the next record of a CSV or TSV file.
+func (r *Reader) readRecord() ([]string, error) {
+	if r.format == CSV {
+		record, err := r.csv.Read()
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		if err != nil {
+			return nil, fmt.Errorf("export: Read - error reading record: %w", err)
+		}
+		r.line, _ = r.csv.FieldPos(0)
+		return record, nil
+	}
+	line, err := r.readLine()
+	if err != nil {
+		return nil, err
+	}
+	record := strings.Split(strings.TrimSuffix(line, "\r"), "\t")
+	for i, value := range record {
+		unescaped, err := tsvUnescape(value)
+		if err != nil {
+			return nil, fmt.Errorf("export: Read - line: %d: %w", r.line, err)
+		}
+		record[i] = unescaped
+	}
+	return record, nil
+}
+
+// tsvUnescape reverses the escaping of tsvEscaper.
+func tsvUnescape(value string) (string, error) {
+	if !strings.Contains(value, `\`) {
+		return value, nil
+	}
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' {
+			b.WriteByte(value[i])
+			continue
+		}
+		i++
+		if i == len(value) {
+			return "", fmt.Errorf("invalid escape sequence at the end of %q", value)
+		}
+		switch value[i] {
+		case '\\':
+			b.WriteByte('\\')
+		case 't':
+			b.WriteByte('\t')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		default:
+			return "", fmt.Errorf("invalid escape sequence \\%c in %q", value[i], value)
+		}
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,234 @@
+// Package export writes news articles to JSON Lines, CSV and TSV files, and reads them back.
+//
+// Articles are written as they are received, without buffering them in memory, so a Writer can consume
+// the Stream of a request of any size. List fields (countries, categories, AI tags...) are flattened as
+// CSV records in a single column, and the sentiment statistics in one column per sentiment (see Columns).
+//
+//	file, _ := os.Create("bitcoin.csv")
+//	defer file.Close()
+//	w, err := export.NewWriter(file, export.CSV, export.WithColumns("article_id", "pubDate", "title", "country"))
+//	if err != nil {
+//		return err
+//	}
+//	articles, errChan := client.NewsArchive.Stream(ctx, "bitcoin")
+//	count, err := w.WriteStream(articles, errChan)
+//
+// A Reader reconstructs the articles from a file written by a Writer.
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"strings"
+
+	"github.com/sicamois/newsdata"
+)
+
+// Format is the format of an export.
+type Format string
+
+// Formats supported by Writer and Reader.
+const (
+	JSONL Format = "jsonl" // JSON Lines: one JSON object per line, in the canonical JSON format of newsdata.NewsArticle
+	CSV   Format = "csv"   // Comma-separated values (RFC 4180), with a header row. \r\n in a field is read back as \n.
+	TSV   Format = "tsv"   // Tab-separated values, with a header row. Tabs, newlines and backslashes are escaped (\t, \n, \r, \\).
+)
+
+// Option is a functional option for configuring a Writer.
+type Option func(*Writer)
+
+// WithColumns selects the columns written, in this order. See Columns for the available columns.
+//
+// If no columns are selected, all the columns are written. For JSONL, the fields of the selected
+// columns are written, the sentiment columns selecting the sentiment_stats field.
+func WithColumns(names ...string) Option {
+	return func(w *Writer) {
+		w.names = names
+	}
+}
+
+// Writer writes articles in an export format.
+//
+// The header of CSV and TSV files is written with the first article. Flush must be called once all the articles are written.
+// A Writer is not safe for concurrent use.
+type Writer struct {
+	w       io.Writer
+	format  Format
+	names   []string
+	columns []column
+	csv     *csv.Writer
+	header  bool // Whether the header was written
+}
+
+// NewWriter creates a Writer writing to w in format.
+//
+// It fails if the format or a selected column is unknown.
+func NewWriter(w io.Writer, format Format, opts ...Option) (*Writer, error) {
+	ew := &Writer{w: w, format: format}
+	for _, opt := range opts {
+		opt(ew)
+	}
+	columns, err := lookupColumns(ew.names)
+	if err != nil {
+		return nil, err
+	}
+	ew.columns = columns
+	switch format {
+	case JSONL, TSV:
+	case CSV:
+		ew.csv = csv.NewWriter(w)
+	default:
+		return nil, fmt.Errorf("export: unknown format %q", format)
+	}
+	return ew, nil
+}
+
+// Write writes an article.
+func (w *Writer) Write(article *newsdata.NewsArticle) error {
+	if w.format == JSONL {
+		return w.writeJSON(article)
+	}
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	record := make([]string, len(w.columns))
+	for i, c := range w.columns {
+		value, err := c.format(article)
+		if err != nil {
+			return fmt.Errorf("export: Write - error formatting column %s of article %s: %w", c.name, article.Id, err)
+		}
+		record[i] = value
+	}
+	return w.writeRecord(record)
+}
+
+// writeHeader writes the header of a CSV or TSV file, if not written yet.
+func (w *Writer) writeHeader() error {
+	if w.header || w.format == JSONL {
+		return nil
+	}
+	header := make([]string, len(w.columns))
+	for i, c := range w.columns {
+		header[i] = c.name
+	}
+	if err := w.writeRecord(header); err != nil {
+		return err
+	}
+	w.header = true
+	return nil
+}
+
+// writeRecord writes a CSV or TSV record.
+func (w *Writer) writeRecord(record []string) error {
+	if w.format == CSV {
+		if err := w.csv.Write(record); err != nil {
+			return fmt.Errorf("export: Write - error writing record: %w", err)
+		}
+		return nil
+	}
+	for i, value := range record {
+		record[i] = tsvEscaper.Replace(value)
+	}
+	if _, err := io.WriteString(w.w, strings.Join(record, "\t")+"\n"); err != nil {
+		return fmt.Errorf("export: Write - error writing record: %w", err)
+	}
+	return nil
+}
+
+// writeJSON writes an article as a JSON line, with only the fields of the selected columns if any.
+func (w *Writer) writeJSON(article *newsdata.NewsArticle) error {
+	data, err := json.Marshal(article)
+	if err != nil {
+		return fmt.Errorf("export: Write - error marshalling article %s: %w", article.Id, err)
+	}
+	if len(w.names) > 0 {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return fmt.Errorf("export: Write - error marshalling article %s: %w", article.Id, err)
+		}
+		var buf bytes.Buffer
+		written := make(map[string]bool, len(w.columns))
+		buf.WriteByte('{')
+		for _, c := range w.columns {
+			if written[c.jsonName] {
+				continue
+			}
+			if len(written) > 0 {
+				buf.WriteByte(',')
+			}
+			written[c.jsonName] = true
+			name, _ := json.Marshal(c.jsonName)
+			buf.Write(name)
+			buf.WriteByte(':')
+			buf.Write(fields[c.jsonName])
+		}
+		buf.WriteByte('}')
+		data = buf.Bytes()
+	}
+	if _, err := w.w.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("export: Write - error writing article %s: %w", article.Id, err)
+	}
+	return nil
+}
+
+// Flush writes any buffered data to the underlying writer, and the header of a CSV or TSV file if no article was written.
+func (w *Writer) Flush() error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	if w.csv != nil {
+		w.csv.Flush()
+		if err := w.csv.Error(); err != nil {
+			return fmt.Errorf("export: Flush - error writing records: %w", err)
+		}
+	}
+	return nil
+}
+
+// WriteStream writes the articles of the channels returned by a Stream method, then flushes the writer.
+// It returns the number of articles written, and the error of the stream or of the writer.
+//
+// The articles are written as they are received. If writing fails, WriteStream returns without draining
+// the channels: cancel the context of the stream to stop it.
+func (w *Writer) WriteStream(articles <-chan *newsdata.NewsArticle, errs <-chan error) (int, error) {
+	count := 0
+	for article := range articles {
+		if err := w.Write(article); err != nil {
+			return count, err
+		}
+		count++
+	}
+	if err := w.Flush(); err != nil {
+		return count, err
+	}
+	if err, ok := <-errs; ok && err != nil {
+		return count, err
+	}
+	return count, nil
+}
+
+// WriteAll writes the articles of an iterator returned by an All method, then flushes the writer.
+// It returns the number of articles written, and the error of the iterator or of the writer.
+func (w *Writer) WriteAll(seq iter.Seq2[*newsdata.NewsArticle, error]) (int, error) {
+	count := 0
+	for article, err := range seq {
+		if err != nil {
+			if flushErr := w.Flush(); flushErr != nil {
+				return count, flushErr
+			}
+			return count, err
+		}
+		if err := w.Write(article); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, w.Flush()
+}
+
+// tsvEscaper escapes the characters that cannot appear in a TSV field.
+var tsvEscaper = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
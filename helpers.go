@@ -1,46 +1,217 @@
 package newsdata
 
 import (
+	"bytes"
 	"context"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log/slog"
+	"strconv"
 	"strings"
 	"time"
 )
 
+// restrictedPrefix is the prefix of the placeholders sent by the API instead of the values of the fields
+// not available in the current plan (e.g. "ONLY AVAILABLE IN PROFESSIONAL AND CORPORATE PLANS").
+const restrictedPrefix = "ONLY AVAILABLE IN "
+
+// isRestricted reports whether value is a plan restriction placeholder.
+func isRestricted(value string) bool {
+	return strings.HasPrefix(strings.ToUpper(strings.TrimSpace(value)), restrictedPrefix)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for NewsArticle.
+// It decodes the article fields, then clears the string fields holding a plan restriction placeholder.
+// The publication date is parsed in the timezone of the article (PubDateTZ), UTC if it is unknown.
+func (a *NewsArticle) UnmarshalJSON(b []byte) error {
+	// newsArticle has the fields of NewsArticle but not its methods, to avoid an infinite recursion
+	type newsArticle NewsArticle
+	aux := struct {
+		*newsArticle
+		PubDate *string `json:"pubDate"` // Parsed once PubDateTZ is known
+	}{newsArticle: (*newsArticle)(a)}
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+	for _, field := range []*string{&a.Description, &a.Content, &a.VideoURL, &a.ImageURL, &a.Sentiment} {
+		if isRestricted(*field) {
+			*field = ""
+		}
+	}
+	a.PubDate = DateTime{}
+	if aux.PubDate != nil {
+		date, err := parseDateTime(*aux.PubDate, articleLocation(a.PubDateTZ))
+		if err != nil {
+			return err
+		}
+		a.PubDate = date
+	}
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface for DateTime.
+// It formats the date in RFC 3339 format with nanoseconds, and the zero time as null.
+func (t DateTime) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.Format(time.RFC3339Nano))
+}
+
 // UnmarshalJSON implements the json.Unmarshaler interface for DateTime.
-// It parses the date string using the time.DateTime format and handles null values.
+// It parses the date string using the time.DateTime format used by the API (in UTC) or the RFC 3339 format
+// used by MarshalJSON, and handles null and empty values.
 func (t *DateTime) UnmarshalJSON(b []byte) error {
 	if string(b) == "null" {
+		*t = DateTime{}
 		return nil
 	}
-	date, err := time.Parse(time.DateTime, strings.Trim(string(b), `"`))
-	if err != nil {
+	var value string
+	if err := json.Unmarshal(b, &value); err != nil {
 		return fmt.Errorf("unmarshalDateTime - error unmarshalling date time - error: %w", err)
 	}
-	t.Time = date
+	return t.UnmarshalText([]byte(value))
+}
+
+// MarshalText implements the encoding.TextMarshaler interface for DateTime.
+// It formats the date in RFC 3339 format with nanoseconds, and the zero time as an empty string.
+func (t DateTime) MarshalText() ([]byte, error) {
+	if t.IsZero() {
+		return []byte{}, nil
+	}
+	return []byte(t.Format(time.RFC3339Nano)), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface for DateTime.
+// It parses the date using the time.DateTime (in UTC) or the RFC 3339 format. An empty string is the zero time.
+func (t *DateTime) UnmarshalText(b []byte) error {
+	date, err := parseDateTime(string(b), time.UTC)
+	if err != nil {
+		return err
+	}
+	*t = date
 	return nil
 }
 
+// parseDateTime parses a date in the time.DateTime format, in loc, or in the RFC 3339 format.
+// Dates in the RFC 3339 format are set in loc if their offset matches, so that the location survives a round trip.
+// An empty string is the zero time.
+func parseDateTime(value string, loc *time.Location) (DateTime, error) {
+	if value == "" {
+		return DateTime{}, nil
+	}
+	date, err := time.ParseInLocation(time.DateTime, value, loc)
+	if err == nil {
+		return DateTime{Time: date}, nil
+	}
+	date, rfcErr := time.Parse(time.RFC3339Nano, value)
+	if rfcErr != nil {
+		return DateTime{}, fmt.Errorf("unmarshalDateTime - error unmarshalling date time - error: %w", err)
+	}
+	_, offset := date.Zone()
+	if _, locOffset := date.In(loc).Zone(); locOffset == offset {
+		date = date.In(loc)
+	}
+	return DateTime{Time: date}, nil
+}
+
+// MarshalJSON implements the json.Marshaler interface for Tags.
+// It formats the tags as an array, and empty tags as null.
+func (t Tags) MarshalJSON() ([]byte, error) {
+	if len(t) == 0 {
+		return []byte("null"), nil
+	}
+	return json.Marshal([]string(t))
+}
+
 // UnmarshalJSON implements the json.Unmarshaler interface for Tags.
-// It handles special cases where the API returns restriction messages or null values,
-// and splits comma-separated tag strings into slices.
+// It accepts both arrays of strings and comma-separated strings, trims the tags,
+// and drops empty tags and the restriction messages returned by the API for the fields not available in the plan.
+// null, empty and restricted values are decoded as nil.
 func (t *Tags) UnmarshalJSON(b []byte) error {
-	value := string(b)
-	if value == "null" || strings.HasPrefix(value, "ONLY AVAILABLE IN ") {
+	var value any
+	if err := json.Unmarshal(b, &value); err != nil {
+		return fmt.Errorf("unmarshalTags - error unmarshalling tags - error: %w", err)
+	}
+	var values []string
+	switch v := value.(type) {
+	case nil:
+	case string:
+		values = strings.Split(v, ",")
+	case []any:
+		values = make([]string, 0, len(v))
+		for _, item := range v {
+			switch item := item.(type) {
+			case nil:
+			case string:
+				values = append(values, item)
+			default:
+				return fmt.Errorf("invalid tags: %s", b)
+			}
+		}
+	default:
+		return fmt.Errorf("invalid tags: %s", b)
+	}
+	var tags Tags
+	for _, tag := range values {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || isRestricted(tag) {
+			continue
+		}
+		tags = append(tags, tag)
+	}
+	*t = tags
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface for Tags.
+// It formats the tags as a CSV record, quoting the tags containing commas or quotes.
+func (t Tags) MarshalText() ([]byte, error) {
+	if len(t) == 0 {
+		return []byte{}, nil
+	}
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(t); err != nil {
+		return nil, fmt.Errorf("marshalTags - error marshalling tags - error: %w", err)
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, fmt.Errorf("marshalTags - error marshalling tags - error: %w", err)
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface for Tags.
+// It parses a CSV record as written by MarshalText. An empty string is decoded as nil.
+func (t *Tags) UnmarshalText(b []byte) error {
+	if len(b) == 0 {
 		*t = nil
 		return nil
 	}
-	tags := strings.Split(string(b), ",")
-	*t = make([]string, len(tags))
-	for i, tag := range tags {
-		(*t)[i] = tag
+	r := csv.NewReader(bytes.NewReader(b))
+	r.TrimLeadingSpace = true
+	tags, err := r.Read()
+	if err != nil {
+		return fmt.Errorf("unmarshalTags - error unmarshalling tags - error: %w", err)
 	}
+	*t = tags
 	return nil
 }
 
+// MarshalJSON implements the json.Marshaler interface for SentimentStats.
+// It formats the statistics as an object, and the zero value (no statistics available) as null.
+func (t SentimentStats) MarshalJSON() ([]byte, error) {
+	if t == (SentimentStats{}) {
+		return []byte("null"), nil
+	}
+	// sentimentStats has the fields of SentimentStats but not its methods, to avoid an infinite recursion
+	type sentimentStats SentimentStats
+	return json.Marshal(sentimentStats(t))
+}
+
 // UnmarshalJSON implements the json.Unmarshaler interface for SentimentStats.
 // It handles cases where the API returns error messages for plan restrictions,
 // null values, and parses the sentiment statistics into their respective fields.
@@ -76,6 +247,49 @@ func (t *SentimentStats) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalText implements the encoding.TextMarshaler interface for SentimentStats.
+// It formats the statistics as "positive=91.27,neutral=8.1,negative=0.63", and the zero value as an empty string.
+func (t SentimentStats) MarshalText() ([]byte, error) {
+	if t == (SentimentStats{}) {
+		return []byte{}, nil
+	}
+	return fmt.Appendf(nil, "positive=%s,neutral=%s,negative=%s",
+		strconv.FormatFloat(t.Positive, 'g', -1, 64),
+		strconv.FormatFloat(t.Neutral, 'g', -1, 64),
+		strconv.FormatFloat(t.Negative, 'g', -1, 64),
+	), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface for SentimentStats.
+// It parses the statistics as written by MarshalText. An empty string is decoded as the zero value.
+func (t *SentimentStats) UnmarshalText(b []byte) error {
+	*t = SentimentStats{}
+	if len(b) == 0 {
+		return nil
+	}
+	for _, stat := range strings.Split(string(b), ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(stat), "=")
+		if !ok {
+			return fmt.Errorf("invalid sentiment stats: %s", b)
+		}
+		score, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("unmarshalSentimentStats - error unmarshalling sentiment stats - error: %w", err)
+		}
+		switch name {
+		case "positive":
+			t.Positive = score
+		case "neutral":
+			t.Neutral = score
+		case "negative":
+			t.Negative = score
+		default:
+			return fmt.Errorf("invalid sentiment stats: %s", b)
+		}
+	}
+	return nil
+}
+
 // Logger Helpers
 
 // levelHandler wraps a slog.Handler with level-based filtering capabilities.
@@ -125,9 +339,15 @@ func (h *levelHandler) Handler() slog.Handler {
 }
 
 // newCustomLogger creates a new slog.Logger with level-based filtering.
-// It configures the logger with the specified writer and minimum log level.
-func newCustomLogger(w io.Writer, level slog.Level) *slog.Logger {
-	th := slog.NewTextHandler(w, nil)
-	logger := slog.New(newlevelHandler(level, th, w))
+// It configures the logger with the specified writer, format (text or JSON) and minimum log level.
+func newCustomLogger(w io.Writer, level slog.Level, json bool) *slog.Logger {
+	handlerOptions := &slog.HandlerOptions{Level: level}
+	var h slog.Handler
+	if json {
+		h = slog.NewJSONHandler(w, handlerOptions)
+	} else {
+		h = slog.NewTextHandler(w, handlerOptions)
+	}
+	logger := slog.New(newlevelHandler(level, h, w))
 	return logger
 }
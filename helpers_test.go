@@ -0,0 +1,192 @@
+package newsdata
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+// TestArticlesGolden decodes the API responses in testdata/articles and compares the decoded articles
+// with the golden files. Run with -update to regenerate the golden files.
+func TestArticlesGolden(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "articles", "*.json"))
+	if err != nil {
+		t.Fatalf("Error listing test files: %v", err)
+	}
+	if len(files) == 0 {
+		t.Fatalf("No test file found")
+	}
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatalf("Error reading test file: %v", err)
+			}
+			var res newsResponse
+			if err := json.Unmarshal(data, &res); err != nil {
+				t.Fatalf("Error decoding response: %v", err)
+			}
+			got, err := json.MarshalIndent(res.Articles, "", "  ")
+			if err != nil {
+				t.Fatalf("Error encoding articles: %v", err)
+			}
+			got = append(got, '\n')
+
+			golden := strings.TrimSuffix(file, ".json") + ".golden"
+			if *update {
+				if err := os.WriteFile(golden, got, 0644); err != nil {
+					t.Fatalf("Error writing golden file: %v", err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("Error reading golden file: %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Fatalf("Decoded articles do not match %s:\n%s", golden, got)
+			}
+		})
+	}
+}
+
+func TestTagsUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		json string
+		want Tags
+	}{
+		{`null`, nil},
+		{`""`, nil},
+		{`[]`, nil},
+		{`"ONLY AVAILABLE IN PROFESSIONAL AND CORPORATE PLANS"`, nil},
+		{`"technology"`, Tags{"technology"}},
+		{`"technology, science ,"`, Tags{"technology", "science"}},
+		{`["technology", "science"]`, Tags{"technology", "science"}},
+		{`["a,b", null, " ", "c"]`, Tags{"a,b", "c"}},
+		{`["technology", "only available in corporate plans"]`, Tags{"technology"}},
+	}
+	for _, test := range tests {
+		var tags Tags
+		if err := json.Unmarshal([]byte(test.json), &tags); err != nil {
+			t.Fatalf("Error decoding %s: %v", test.json, err)
+		}
+		if !slices.Equal(tags, test.want) {
+			t.Fatalf("Tags decoded from %s should be %q, got %q", test.json, test.want, tags)
+		}
+	}
+
+	for _, invalid := range []string{`42`, `{"tag": "technology"}`, `["technology", 42]`} {
+		var tags Tags
+		if err := json.Unmarshal([]byte(invalid), &tags); err == nil {
+			t.Fatalf("Decoding %s should fail", invalid)
+		}
+	}
+}
+
+// TestArticlesRoundTrip checks that marshaling and unmarshaling the articles of testdata/articles is lossless.
+func TestArticlesRoundTrip(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "articles", "*.json"))
+	if err != nil {
+		t.Fatalf("Error listing test files: %v", err)
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("Error reading test file: %v", err)
+		}
+		var res newsResponse
+		if err := json.Unmarshal(data, &res); err != nil {
+			t.Fatalf("Error decoding %s: %v", file, err)
+		}
+		data, err = json.Marshal(res.Articles)
+		if err != nil {
+			t.Fatalf("Error encoding articles of %s: %v", file, err)
+		}
+		var articles []NewsArticle
+		if err := json.Unmarshal(data, &articles); err != nil {
+			t.Fatalf("Error decoding marshaled articles of %s: %v", file, err)
+		}
+		if !reflect.DeepEqual(articles, res.Articles) {
+			t.Fatalf("Articles of %s changed after a round trip:\n%+v\n%+v", file, res.Articles, articles)
+		}
+	}
+}
+
+func TestDateTimeRoundTrip(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Fatalf("Error loading location: %v", err)
+	}
+	for _, date := range []DateTime{
+		{},
+		{Time: time.Date(2025, 1, 9, 14, 30, 0, 0, time.UTC)},
+		{Time: time.Date(2025, 1, 9, 14, 30, 0, 123456789, paris)},
+	} {
+		data, err := json.Marshal(date)
+		if err != nil {
+			t.Fatalf("Error marshaling %v: %v", date, err)
+		}
+		var decoded DateTime
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Error unmarshaling %s: %v", data, err)
+		}
+		if !decoded.Equal(date.Time) || decoded.Format(time.RFC3339Nano) != date.Format(time.RFC3339Nano) {
+			t.Fatalf("DateTime should be %v after a JSON round trip, got %v", date, decoded)
+		}
+
+		text, err := date.MarshalText()
+		if err != nil {
+			t.Fatalf("Error marshaling %v: %v", date, err)
+		}
+		decoded = DateTime{}
+		if err := decoded.UnmarshalText(text); err != nil {
+			t.Fatalf("Error unmarshaling %s: %v", text, err)
+		}
+		if !decoded.Equal(date.Time) {
+			t.Fatalf("DateTime should be %v after a text round trip, got %v", date, decoded)
+		}
+	}
+
+	var decoded DateTime
+	if err := json.Unmarshal([]byte(`"2025-01-09 14:30:00"`), &decoded); err != nil || !decoded.Equal(time.Date(2025, 1, 9, 14, 30, 0, 0, time.UTC)) {
+		t.Fatalf("DateTime in API format should be decoded: %v, %v", decoded, err)
+	}
+}
+
+func TestTagsAndSentimentStatsTextRoundTrip(t *testing.T) {
+	for _, tags := range []Tags{nil, {"technology"}, {"technology", "santa clara,california", `"quoted"`}} {
+		text, err := tags.MarshalText()
+		if err != nil {
+			t.Fatalf("Error marshaling %q: %v", tags, err)
+		}
+		var decoded Tags
+		if err := decoded.UnmarshalText(text); err != nil {
+			t.Fatalf("Error unmarshaling %s: %v", text, err)
+		}
+		if !slices.Equal(decoded, tags) {
+			t.Fatalf("Tags should be %q after a text round trip, got %q", tags, decoded)
+		}
+	}
+
+	for _, stats := range []SentimentStats{{}, {Positive: 91.27, Neutral: 8.1, Negative: 0.63}, {Neutral: 100}} {
+		text, err := stats.MarshalText()
+		if err != nil {
+			t.Fatalf("Error marshaling %v: %v", stats, err)
+		}
+		var decoded SentimentStats
+		if err := decoded.UnmarshalText(text); err != nil {
+			t.Fatalf("Error unmarshaling %s: %v", text, err)
+		}
+		if decoded != stats {
+			t.Fatalf("SentimentStats should be %v after a text round trip, got %v", stats, decoded)
+		}
+	}
+}
@@ -11,6 +11,8 @@ import (
 	"net/url"
 	"os"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -19,21 +21,42 @@ import (
 //
 // The client handles HTTP requests, authentication, and logging configurations.
 type NewsDataClient struct {
-	apiKey      string
-	baseURL     string
-	httpClient  *http.Client
-	logger      *slog.Logger
-	LatestNews  *NewsService
-	NewsArchive *NewsService
-	CryptoNews  *NewsService
-	Sources     *SourcesService
+	apiKey       string
+	baseURL      string
+	httpClient   *http.Client
+	logger       *slog.Logger
+	retryPolicy  RetryPolicy
+	rateLimiter  *rateLimiter
+	creditBudget int
+	strictParams bool
+	location     *time.Location
+	creditsUsed  atomic.Int64
+	quotaMu      sync.Mutex
+	quota        Quota
+	LatestNews   *LatestNewsService
+	NewsArchive  *NewsArchiveService
+	CryptoNews   *CryptoNewsService
+	MarketNews   *MarketNewsService
+	Sources      *SourcesService
 }
 
 type clientOptions struct {
 	apiKey             string
+	logger             *slog.Logger
 	customLoggerWriter io.Writer
+	loggerJSON         bool
 	loggerLevel        slog.Level
+	loggerLevelSet     bool
 	timeout            time.Duration
+	retryPolicy        RetryPolicy
+	rateLimitRequests  int
+	rateLimitPeriod    time.Duration
+	creditBudget       int
+	baseURL            string
+	httpClient         *http.Client
+	transport          http.RoundTripper
+	strictParams       bool
+	location           *time.Location
 }
 
 // NewsDataClientOption is a functional option for configuring the NewsDataClient.
@@ -58,12 +81,99 @@ func WithTimeout(timeout time.Duration) NewsDataClientOption {
 	}
 }
 
+// WithLogger sets the logger used by the client, including for the request parameters validation warnings.
+//
+// If no logger is provided, the client uses a copy of slog.Default().
+func WithLogger(logger *slog.Logger) NewsDataClientOption {
+	return func(o *clientOptions) {
+		o.logger = logger
+	}
+}
+
+// WithLogWriter makes the client log to w with a text handler.
+//
+// It takes precedence over WithLogger. Use io.Discard to silence the client.
+func WithLogWriter(w io.Writer) NewsDataClientOption {
+	return func(o *clientOptions) {
+		o.customLoggerWriter = w
+		o.loggerJSON = false
+	}
+}
+
+// WithJSONLogWriter makes the client log to w with a JSON handler.
+//
+// It takes precedence over WithLogger.
+func WithJSONLogWriter(w io.Writer) NewsDataClientOption {
+	return func(o *clientOptions) {
+		o.customLoggerWriter = w
+		o.loggerJSON = true
+	}
+}
+
+// WithLogLevel sets the minimum level of the messages logged by the client.
+//
+// If no level is provided, the client logs at slog.LevelInfo with WithLogWriter or WithJSONLogWriter, and defers to the
+// logger's own level otherwise.
+func WithLogLevel(level slog.Level) NewsDataClientOption {
+	return func(o *clientOptions) {
+		o.loggerLevel = level
+		o.loggerLevelSet = true
+	}
+}
+
+// WithStrictParams makes the requests fail with a *ParamError when some request parameters are invalid.
+//
+// By default, invalid values are logged and dropped (or truncated), and the request is sent anyway.
+func WithStrictParams() NewsDataClientOption {
+	return func(o *clientOptions) {
+		o.strictParams = true
+	}
+}
+
+// WithLocation converts the publication dates of all the articles retrieved by the client to loc (see NormalizeTimezone).
+//
+// By default, publication dates are in the timezone of the article (PubDateTZ), or in the timezone requested
+// with WithTimezone.
+func WithLocation(loc *time.Location) NewsDataClientOption {
+	return func(o *clientOptions) {
+		o.location = loc
+	}
+}
+
+// WithBaseURL sets the base URL of the API, e.g. to use a caching proxy or a fake server in tests.
+//
+// If no base URL is provided, the client will use https://newsdata.io/api/1.
+func WithBaseURL(baseURL string) NewsDataClientOption {
+	return func(o *clientOptions) {
+		o.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithHTTPClient sets the http client used to send the requests.
+//
+// The client is used as is: the timeout set with WithTimeout is ignored.
+func WithHTTPClient(httpClient *http.Client) NewsDataClientOption {
+	return func(o *clientOptions) {
+		o.httpClient = httpClient
+	}
+}
+
+// WithTransport sets the http.RoundTripper used by the http client, e.g. to configure a proxy or TLS settings.
+//
+// If used with WithHTTPClient, the transport replaces the one of the provided http client (which is not modified).
+func WithTransport(transport http.RoundTripper) NewsDataClientOption {
+	return func(o *clientOptions) {
+		o.transport = transport
+	}
+}
+
 // NewClient creates a new NewsData API client with the provided options.
 //
 // If no API key is provided via options, it attempts to read from the NEWSDATA_API_KEY
 // environment variable. It will panic if no API key is available.
 func NewClient(opts ...NewsDataClientOption) *NewsDataClient {
 	options := &clientOptions{
+		baseURL:     "https://newsdata.io/api/1",
 		timeout:     5 * time.Second,
 		loggerLevel: slog.LevelInfo,
 	}
@@ -76,27 +186,63 @@ func NewClient(opts ...NewsDataClientOption) *NewsDataClient {
 			panic("NEWSDATA_API_KEY is not set")
 		}
 	}
+	httpClient := options.httpClient
+	if httpClient == nil {
+		httpClient = &http.Client{
+			Timeout: options.timeout,
+		}
+	}
+	if options.transport != nil {
+		httpClientCopy := *httpClient
+		httpClientCopy.Transport = options.transport
+		httpClient = &httpClientCopy
+	}
 
 	client := &NewsDataClient{
 		// newsdata.io API base URL
-		baseURL: "https://newsdata.io/api/1",
+		baseURL: options.baseURL,
 		// newsdata.io API key
 		apiKey: options.apiKey,
 		// HTTP client is a *http.Client that can be customized
-		httpClient: &http.Client{
-			Timeout: options.timeout,
-		},
+		httpClient: httpClient,
+		// Retry policy applied to every request
+		retryPolicy: options.retryPolicy,
+		// Client-side rate limiter shared by all services (nil if disabled)
+		rateLimiter: newRateLimiter(options.rateLimitRequests, options.rateLimitPeriod),
+		// Maximum number of credits the client may spend (0 means no limit)
+		creditBudget: options.creditBudget,
+		// Whether invalid request parameters make the requests fail
+		strictParams: options.strictParams,
+		// Location of the publication dates, nil to keep the timezone of the articles
+		location: options.location,
+		// Credits snapshot, unknown until the first response
+		quota: Quota{Limit: -1, Remaining: -1},
 	}
-	defaultLogger := *slog.Default()
-	defaultCopy := &defaultLogger
-	client.logger = defaultCopy.With(slog.String("package", "newsdata"))
+	client.logger = newClientLogger(options).With(slog.String("package", "newsdata"))
 	client.LatestNews = client.newLatestNewsService()
 	client.NewsArchive = client.newNewsArchiveService()
 	client.CryptoNews = client.newCryptoNewsService()
+	client.MarketNews = client.newMarketNewsService()
 	client.Sources = client.newSourcesService()
 	return client
 }
 
+// newClientLogger creates the client logger from the logger options.
+func newClientLogger(options *clientOptions) *slog.Logger {
+	if options.customLoggerWriter != nil {
+		return newCustomLogger(options.customLoggerWriter, options.loggerLevel, options.loggerJSON)
+	}
+	logger := options.logger
+	if logger == nil {
+		defaultLogger := *slog.Default()
+		logger = &defaultLogger
+	}
+	if options.loggerLevelSet {
+		logger = slog.New(newlevelHandler(options.loggerLevel, logger.Handler(), nil))
+	}
+	return logger
+}
+
 // errorResponse represents the API response when an error happened.
 type errorResponse struct {
 	Status string `json:"status"` // Response status ("error")
@@ -129,13 +275,64 @@ func (c *NewsDataClient) buildHttpRequest(endpoint endpoint, params requestParam
 	return httpReq, nil
 }
 
-// fetch sends an HTTP request and decodes the response.
-func (c *NewsDataClient) fetch(context context.Context, endpoint endpoint, params requestParams) ([]byte, error) {
+// fetch sends an HTTP request and returns the response body and the credits snapshot.
+//
+// Transient failures are retried according to the client's retry policy.
+// It fails with ErrCreditBudgetExceeded if the client's credit budget is spent, including between two attempts.
+func (c *NewsDataClient) fetch(ctx context.Context, endpoint endpoint, params requestParams) ([]byte, Quota, error) {
+	for attempt := 1; ; attempt++ {
+		body, quota, err := c.fetchOnce(ctx, endpoint, params)
+		if err == nil {
+			return body, quota, nil
+		}
+		if ctx.Err() != nil || errors.Is(err, ErrCreditBudgetExceeded) || !c.retryPolicy.shouldRetry(attempt, err) {
+			return nil, quota, err
+		}
+		delay := c.retryPolicy.delay(attempt, err)
+		c.logger.Warn("request failed, retrying", "endpoint", endpoint.String(), "attempt", attempt, "maxAttempts", c.retryPolicy.MaxAttempts, "delay", delay, "error", err)
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, quota, fmt.Errorf("fetch - context done while waiting to retry: %w", errors.Join(ctx.Err(), err))
+		}
+	}
+}
+
+// reserveCredit counts a credit as spent, unless the credit budget is already spent.
+// The credit must be refunded with refundCredit if the request is not sent.
+func (c *NewsDataClient) reserveCredit() bool {
+	used := c.creditsUsed.Add(1)
+	if c.creditBudget > 0 && used > int64(c.creditBudget) {
+		c.creditsUsed.Add(-1)
+		return false
+	}
+	return true
+}
+
+// refundCredit cancels the reservation of a credit for a request that was not sent.
+func (c *NewsDataClient) refundCredit() {
+	c.creditsUsed.Add(-1)
+}
+
+// fetchOnce sends a single HTTP request and returns the response body and the credits snapshot.
+//
+// A credit is spent as soon as the request is sent, whatever its outcome, since the API may bill it.
+func (c *NewsDataClient) fetchOnce(ctx context.Context, endpoint endpoint, params requestParams) ([]byte, Quota, error) {
+	if !c.reserveCredit() {
+		return nil, Quota{}, fmt.Errorf("fetch - %d credits spent: %w", c.creditBudget, ErrCreditBudgetExceeded)
+	}
+	if err := c.rateLimiter.wait(ctx); err != nil {
+		c.refundCredit()
+		return nil, Quota{}, fmt.Errorf("fetch - error waiting for rate limiter: %w", err)
+	}
 	start := time.Now()
 
 	httpReq, err := c.buildHttpRequest(endpoint, params)
 	if err != nil {
-		return nil, fmt.Errorf("fetch: error building HTTP request: %w", err)
+		c.refundCredit()
+		return nil, Quota{}, fmt.Errorf("fetch: error building HTTP request: %w", err)
 	}
 
 	var resp *http.Response
@@ -160,26 +357,34 @@ func (c *NewsDataClient) fetch(context context.Context, endpoint endpoint, param
 		c.logger.Debug("request completed", attrs...)
 	}()
 	httpReq.Header.Set("X-ACCESS-KEY", c.apiKey)
-	httpReq = httpReq.WithContext(context)
+	httpReq = httpReq.WithContext(ctx)
 
 	resp, err = c.httpClient.Do(httpReq)
 	if err != nil {
-		return nil, fmt.Errorf("fetch - error executing request - url: %s: %w", httpReq.URL.String(), err)
+		return nil, Quota{}, fmt.Errorf("fetch - error executing request - url: %s: %w", httpReq.URL.String(), err)
 	}
+	quota := c.setQuota(parseQuota(resp.Header, time.Now()))
 	body, err := io.ReadAll(resp.Body)
 	defer resp.Body.Close()
 	if err != nil {
-		return nil, fmt.Errorf("fetch - error reading response body - url: %s: %w", httpReq.URL.String(), err)
+		return nil, quota, fmt.Errorf("fetch - error reading response body - url: %s: %w", httpReq.URL.String(), err)
 	}
 
 	// Handle non-200 status codes.
 	if resp.StatusCode != http.StatusOK {
+		apiErr := &APIError{
+			StatusCode: resp.StatusCode,
+			Endpoint:   string(endpoint),
+			Params:     sanitizeParams(params),
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+		}
 		var errorData errorResponse
-		if err := json.Unmarshal(body, &errorData); err != nil {
-			return nil, fmt.Errorf("fetch - error unmarshalling error response - url: %s: %w", httpReq.URL.String(), err)
+		if err := json.Unmarshal(body, &errorData); err == nil {
+			apiErr.Code = errorData.Error.Code
+			apiErr.Message = errorData.Error.Message
 		}
-		return nil, fmt.Errorf("fetch - error reading response body - url: %s: %w", httpReq.URL.String(), errors.New(errorData.Error.Message))
+		return nil, quota, fmt.Errorf("fetch - API error - url: %s: %w", httpReq.URL.String(), apiErr)
 	}
 
-	return body, nil
+	return body, quota, nil
 }
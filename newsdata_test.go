@@ -3,8 +3,16 @@ package newsdata
 import (
 	"bufio"
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -82,3 +90,260 @@ func TestGetSources(t *testing.T) {
 		t.Fatalf("No sources found")
 	}
 }
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestWithTransport(t *testing.T) {
+	var reqURL string
+	transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		reqURL = r.URL.String()
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader(`{"status":"success","totalResults":1,"results":[{"id":"source"}]}`)),
+		}, nil
+	})
+	client := NewClient(WithAPIKey("test"), WithBaseURL("http://proxy.local/api/1/"), WithTransport(transport))
+	sources, err := client.Sources.Get(context.Background(), WithCountry("fr"))
+	if err != nil {
+		t.Fatalf("Error fetching Sources: %v", err)
+	}
+	if len(sources) != 1 || sources[0].Id != "source" {
+		t.Fatalf("Invalid sources: %v", sources)
+	}
+	if reqURL != "http://proxy.local/api/1/sources?country=fr" {
+		t.Fatalf("Invalid request URL: %s", reqURL)
+	}
+}
+
+func TestWithLogWriter(t *testing.T) {
+	var logs strings.Builder
+	client := NewClient(WithAPIKey("test"), WithJSONLogWriter(&logs), WithLogLevel(slog.LevelWarn))
+	newRequestParams("", client.logger, false, endpointLatestNews, WithCategories("unknown"), WithSize(10))
+	if !strings.Contains(logs.String(), `"level":"WARN"`) || !strings.Contains(logs.String(), `category \"unknown\" is not allowed`) {
+		t.Fatalf("Validation warning should be logged to the writer: %s", logs.String())
+	}
+	client.logger.Info("ignored")
+	if strings.Contains(logs.String(), "ignored") {
+		t.Fatalf("Info message should be filtered: %s", logs.String())
+	}
+}
+
+func TestStrictParams(t *testing.T) {
+	client := NewClient(WithAPIKey("test"), WithBaseURL("http://localhost:0"), WithLogWriter(io.Discard), WithStrictParams())
+	_, err := client.LatestNews.Get(context.Background(), "", 10, WithCountries("us", "xx"), WithSize(100))
+	if !errors.Is(err, ErrInvalidParam) {
+		t.Fatalf("Error should be ErrInvalidParam: %v", err)
+	}
+	var paramErr *ParamError
+	if !errors.As(err, &paramErr) {
+		t.Fatalf("Error should be a *ParamError: %v", err)
+	}
+	if len(paramErr.Issues) != 2 || paramErr.Issues[0].Param != "country" || paramErr.Issues[0].Value != "xx" || paramErr.Issues[1].Param != "size" {
+		t.Fatalf("Invalid issues: %+v", paramErr.Issues)
+	}
+}
+
+func TestEndpointParams(t *testing.T) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Write([]byte(`{"status":"success","totalResults":1,"results":[{"article_id":"1"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test"), WithBaseURL(server.URL))
+	_, err := client.CryptoNews.Get(context.Background(), "", 1, WithCoins("btc"), WithTimeframe(2, 0), WithFromDate(time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)), WithLanguages("en"))
+	if err != nil {
+		t.Fatalf("Error fetching Crypto News: %v", err)
+	}
+	if query.Get("coin") != "btc" || query.Get("timeframe") != "2" || query.Get("from_date") != "2025-01-02" || query.Get("language") != "en" {
+		t.Fatalf("Invalid query: %v", query)
+	}
+}
+
+// newPaginatedServer returns a test server serving pages of 2 articles, failing after failAfter pages (if > 0).
+func newPaginatedServer(totalResults int, failAfter int) (*httptest.Server, *int) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if failAfter > 0 && calls > failAfter {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"status":"error","results":{"message":"Internal error","code":"ServerError"}}`))
+			return
+		}
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		articles := make([]string, 0, 2)
+		for i := page * 2; i < min(page*2+2, totalResults); i++ {
+			articles = append(articles, fmt.Sprintf(`{"article_id":"%d"}`, i))
+		}
+		nextPage := ""
+		if page*2+2 < totalResults {
+			nextPage = fmt.Sprintf("%d", page+1)
+		}
+		fmt.Fprintf(w, `{"status":"success","totalResults":%d,"results":[%s],"nextPage":"%s"}`, totalResults, strings.Join(articles, ","), nextPage)
+	}))
+	return server, &calls
+}
+
+func TestAllAndPages(t *testing.T) {
+	server, calls := newPaginatedServer(5, 0)
+	defer server.Close()
+	client := NewClient(WithAPIKey("test"), WithBaseURL(server.URL))
+
+	articles, err := client.LatestNews.Get(context.Background(), "", 0)
+	if err != nil {
+		t.Fatalf("Error fetching Latest News: %v", err)
+	}
+	if len(articles) != 5 || articles[4].Id != "4" {
+		t.Fatalf("Invalid articles: %d - should be 5", len(articles))
+	}
+
+	*calls = 0
+	for page, err := range client.LatestNews.Pages(context.Background(), "") {
+		if err != nil {
+			t.Fatalf("Error fetching Latest News pages: %v", err)
+		}
+		if page.TotalResults != 5 || len(page.Articles) != 2 || page.NextPage != "1" {
+			t.Fatalf("Invalid page: %+v", page)
+		}
+		break
+	}
+	if *calls != 1 {
+		t.Fatalf("Invalid number of calls: %d - should be 1", *calls)
+	}
+}
+
+func TestGetDoesNotDropErrors(t *testing.T) {
+	server, calls := newPaginatedServer(10, 2)
+	defer server.Close()
+	client := NewClient(WithAPIKey("test"), WithBaseURL(server.URL))
+
+	articles, err := client.LatestNews.Get(context.Background(), "", 0)
+	if err == nil {
+		t.Fatalf("Error should not be dropped, got %d articles", len(articles))
+	}
+	*calls = 0
+	count := 0
+	for _, err := range client.LatestNews.All(context.Background(), "") {
+		if err != nil {
+			break
+		}
+		count++
+	}
+	if count != 4 {
+		t.Fatalf("Invalid number of articles before the error: %d - should be 4", count)
+	}
+}
+
+func TestResumeFrom(t *testing.T) {
+	server, _ := newPaginatedServer(5, 0)
+	defer server.Close()
+	client := NewClient(WithAPIKey("test"), WithBaseURL(server.URL))
+
+	var cursor *Cursor
+	for page, err := range client.NewsArchive.Pages(context.Background(), "elections", WithLanguages("en")) {
+		if err != nil {
+			t.Fatalf("Error fetching News Archive pages: %v", err)
+		}
+		cursor = page.Cursor
+		break
+	}
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		t.Fatalf("Error marshalling cursor: %v", err)
+	}
+	var restored Cursor
+	if err := json.Unmarshal(data, &restored); err != nil {
+		t.Fatalf("Error unmarshalling cursor: %v", err)
+	}
+	if restored.PageToken != "1" || restored.ArticlesConsumed != 2 || restored.Params["q"] != "elections" || restored.Params["language"] != "en" {
+		t.Fatalf("Invalid cursor: %+v", restored)
+	}
+
+	articles, err := client.NewsArchive.Get(context.Background(), "", 0, WithResumeFrom(restored))
+	if err != nil {
+		t.Fatalf("Error resuming News Archive: %v", err)
+	}
+	if len(articles) != 3 || articles[0].Id != "2" {
+		t.Fatalf("Invalid resumed articles: %d - should be 3", len(articles))
+	}
+
+	// Parameters placed before the cursor are kept, unless the cursor sets them.
+	params, err := newRequestParams[NewsArchiveParam]("", client.logger, true, endpointNewsArchive, WithLanguages("fr"), WithCategories("politics"), WithResumeFrom(restored))
+	if err != nil {
+		t.Fatalf("Error building resumed params: %v", err)
+	}
+	if params["q"] != "elections" || params["language"] != "en" || params["category"] != "politics" || params["page"] != "1" {
+		t.Fatalf("Invalid resumed params: %v", params)
+	}
+
+	strictClient := NewClient(WithAPIKey("test"), WithBaseURL(server.URL), WithLogWriter(io.Discard), WithStrictParams())
+	if _, err = strictClient.LatestNews.Get(context.Background(), "", 0, WithResumeFrom(restored)); !errors.Is(err, ErrInvalidParam) {
+		t.Fatalf("Resuming from a cursor of another endpoint should fail: %v", err)
+	}
+}
+
+func TestStreamResume(t *testing.T) {
+	failing, _ := newPaginatedServer(7, 2)
+	defer failing.Close()
+	client := NewClient(WithAPIKey("test"), WithBaseURL(failing.URL), WithLogWriter(io.Discard))
+
+	articles, errChan := client.NewsArchive.Stream(context.Background(), "elections")
+	count := 0
+	for range articles {
+		count++
+	}
+	var streamErr *StreamError
+	var apiErr *APIError
+	if err := <-errChan; !errors.As(err, &streamErr) || !errors.As(err, &apiErr) {
+		t.Fatalf("Error should be a *StreamError wrapping an *APIError: %v", err)
+	}
+	if count != 4 || streamErr.Cursor == nil || streamErr.Cursor.PageToken != "2" || streamErr.Cursor.ArticlesConsumed != 4 {
+		t.Fatalf("Invalid stream error: %d articles, cursor %+v - should be 4 articles, cursor at page 2", count, streamErr.Cursor)
+	}
+
+	// Resuming fails again before any page: the cursor is kept.
+	articles, errChan = client.NewsArchive.Stream(context.Background(), "", WithResumeFrom(*streamErr.Cursor))
+	for range articles {
+	}
+	if err := <-errChan; !errors.As(err, &streamErr) || streamErr.Cursor == nil || streamErr.Cursor.PageToken != "2" {
+		t.Fatalf("Failed resumed stream should keep its cursor: %v", err)
+	}
+
+	server, _ := newPaginatedServer(7, 0)
+	defer server.Close()
+	client = NewClient(WithAPIKey("test"), WithBaseURL(server.URL))
+	resumed, err := client.NewsArchive.Get(context.Background(), "", 0, WithResumeFrom(*streamErr.Cursor))
+	if err != nil {
+		t.Fatalf("Error resuming News Archive: %v", err)
+	}
+	if len(resumed) != 3 || resumed[0].Id != "4" {
+		t.Fatalf("Invalid resumed articles: %d - should be 3", len(resumed))
+	}
+}
+
+func TestFetchPage(t *testing.T) {
+	server, _ := newPaginatedServer(5, 0)
+	defer server.Close()
+	client := NewClient(WithAPIKey("test"), WithBaseURL(server.URL))
+
+	page, err := client.LatestNews.FetchPage(context.Background(), "", "")
+	if err != nil {
+		t.Fatalf("Error fetching Latest News page: %v", err)
+	}
+	if page.TotalResults != 5 || page.NextPage != "1" || len(page.Articles) != 2 {
+		t.Fatalf("Invalid first page: %+v", page)
+	}
+	page, err = client.LatestNews.FetchPage(context.Background(), "", "2")
+	if err != nil {
+		t.Fatalf("Error fetching Latest News page: %v", err)
+	}
+	if page.NextPage != "" || page.Cursor != nil || len(page.Articles) != 1 || page.Articles[0].Id != "4" {
+		t.Fatalf("Invalid last page: %+v", page)
+	}
+}
@@ -0,0 +1,231 @@
+package newsdatatest
+
+import (
+	"cmp"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sicamois/newsdata"
+)
+
+// filterArticles returns the articles matching the query parameters, most recent first.
+func filterArticles(articles []newsdata.NewsArticle, query url.Values, now time.Time) []newsdata.NewsArticle {
+	matching := make([]newsdata.NewsArticle, 0, len(articles))
+	priorities := priorityThresholds(articles)
+	for _, article := range articles {
+		if matchArticle(article, query, now, priorities) {
+			matching = append(matching, article)
+		}
+	}
+	slices.SortStableFunc(matching, func(a, b newsdata.NewsArticle) int {
+		return b.PubDate.Time.Compare(a.PubDate.Time)
+	})
+	return matching
+}
+
+func matchArticle(article newsdata.NewsArticle, query url.Values, now time.Time, priorities map[string]int) bool {
+	meta := strings.Join([]string{article.Title, article.Description, strings.Join(article.Keywords, " ")}, " ")
+	if q := query.Get("q"); q != "" && !matchQuery(q, meta+" "+article.Content) {
+		return false
+	}
+	if q := query.Get("qInTitle"); q != "" && !matchQuery(q, article.Title) {
+		return false
+	}
+	if q := query.Get("qInMeta"); q != "" && !matchQuery(q, meta) {
+		return false
+	}
+	if !matchAny(query.Get("id"), []string{article.Id}) ||
+		!matchAny(query.Get("country"), article.Countries) ||
+		!matchAny(query.Get("category"), article.Categories) ||
+		!matchAny(query.Get("language"), []string{article.Language}) ||
+		!matchAny(query.Get("domain"), []string{article.SourceId}) ||
+		!matchAny(query.Get("tag"), article.AiTags) ||
+		!matchAny(query.Get("coin"), article.Coin) ||
+		!matchAny(query.Get("symbol"), article.Symbols) ||
+		!matchAny(query.Get("organization"), article.Organizations) ||
+		!matchAny(query.Get("sentiment"), []string{article.Sentiment}) {
+		return false
+	}
+	if excluded := query.Get("excludecategory"); excluded != "" && matchAny(excluded, article.Categories) {
+		return false
+	}
+	if excluded := query.Get("excludedomain"); excluded != "" && matchAny(excluded, []string{article.SourceId}) {
+		return false
+	}
+	if domainURLs := query.Get("domainurl"); domainURLs != "" && !slices.ContainsFunc(strings.Split(domainURLs, ","), func(domainURL string) bool {
+		return strings.Contains(article.SourceURL, domainURL)
+	}) {
+		return false
+	}
+	if priority := query.Get("prioritydomain"); priority != "" && article.SourcePriority > priorities[priority] {
+		return false
+	}
+	if !matchBinary(query.Get("full_content"), article.Content != "") ||
+		!matchBinary(query.Get("image"), article.ImageURL != "") ||
+		!matchBinary(query.Get("video"), article.VideoURL != "") {
+		return false
+	}
+	if query.Get("removeduplicate") == "1" && article.Duplicate {
+		return false
+	}
+	if from := query.Get("from_date"); from != "" && formatLike(article.PubDate.Time, from) < from {
+		return false
+	}
+	if to := query.Get("to_date"); to != "" && formatLike(article.PubDate.Time, to) > to {
+		return false
+	}
+	if timeframe := parseTimeframe(query.Get("timeframe")); timeframe > 0 && article.PubDate.Time.Before(now.Add(-timeframe)) {
+		return false
+	}
+	return true
+}
+
+// matchQuery reports whether text matches a search query.
+//
+// It supports a simplified version of the API syntax: alternatives separated by OR, terms implicitly
+// joined by AND, quoted phrases, and terms excluded with NOT or a leading "-".
+func matchQuery(query string, text string) bool {
+	text = strings.ToLower(text)
+	for _, alternative := range strings.Split(query, " OR ") {
+		if matchTerms(alternative, text) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchTerms(query string, text string) bool {
+	exclude := false
+	for _, term := range splitTerms(query) {
+		switch {
+		case term == "AND":
+			continue
+		case term == "NOT":
+			exclude = true
+			continue
+		case strings.HasPrefix(term, "-") && len(term) > 1:
+			exclude = true
+			term = term[1:]
+		}
+		term = strings.ToLower(strings.Trim(term, `"()`))
+		if term != "" && strings.Contains(text, term) == exclude {
+			return false
+		}
+		exclude = false
+	}
+	return true
+}
+
+// splitTerms splits a query on spaces, keeping quoted phrases together.
+func splitTerms(query string) []string {
+	var terms []string
+	var term strings.Builder
+	quoted := false
+	for _, r := range query {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			term.WriteRune(r)
+		case r == ' ' && !quoted:
+			if term.Len() > 0 {
+				terms = append(terms, term.String())
+				term.Reset()
+			}
+		default:
+			term.WriteRune(r)
+		}
+	}
+	if term.Len() > 0 {
+		terms = append(terms, term.String())
+	}
+	return terms
+}
+
+// matchAny reports whether one of the comma-separated values is in values. An empty filter matches everything.
+func matchAny(filter string, values []string) bool {
+	if filter == "" {
+		return true
+	}
+	for _, value := range strings.Split(filter, ",") {
+		if slices.ContainsFunc(values, func(v string) bool { return strings.EqualFold(v, value) }) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchBinary matches a pseudo-boolean filter ("1" or "0"). An empty filter matches everything.
+func matchBinary(filter string, value bool) bool {
+	switch filter {
+	case "1":
+		return value
+	case "0":
+		return !value
+	}
+	return true
+}
+
+// formatLike formats t in UTC with the layout of a from_date or to_date parameter, either a date or a date and time,
+// so that they can be compared as strings.
+func formatLike(t time.Time, value string) string {
+	if len(value) > len(time.DateOnly) {
+		return t.UTC().Format(time.DateTime)
+	}
+	return t.UTC().Format(time.DateOnly)
+}
+
+// parseTimeframe parses a timeframe parameter, either in hours ("6") or in minutes ("90m").
+func parseTimeframe(value string) time.Duration {
+	if minutes, ok := strings.CutSuffix(value, "m"); ok {
+		n, _ := strconv.Atoi(minutes)
+		return time.Duration(n) * time.Minute
+	}
+	n, _ := strconv.Atoi(value)
+	return time.Duration(n) * time.Hour
+}
+
+// priorityThresholds returns the maximum source priority of each priority domain.
+//
+// As for the API, top, medium and low are the top 10%, 30% and 50% of the sources (the lower the priority, the better).
+func priorityThresholds(articles []newsdata.NewsArticle) map[string]int {
+	priorities := make(map[string]int)
+	for _, article := range articles {
+		priorities[article.SourceId] = article.SourcePriority
+	}
+	sorted := make([]int, 0, len(priorities))
+	for _, priority := range priorities {
+		sorted = append(sorted, priority)
+	}
+	slices.SortFunc(sorted, cmp.Compare)
+	threshold := func(percent int) int {
+		if len(sorted) == 0 {
+			return 0
+		}
+		return sorted[max((len(sorted)*percent+99)/100-1, 0)]
+	}
+	return map[string]int{
+		"top":    threshold(10),
+		"medium": threshold(30),
+		"low":    threshold(50),
+	}
+}
+
+// filterSources returns the sources matching the query parameters.
+func filterSources(sources []newsdata.Source, query url.Values) []newsdata.Source {
+	matching := make([]newsdata.Source, 0, len(sources))
+	for _, source := range sources {
+		if !matchAny(query.Get("country"), source.Countries) ||
+			!matchAny(query.Get("category"), source.Categories) ||
+			!matchAny(query.Get("language"), source.Languages) {
+			continue
+		}
+		if domainURL := query.Get("domainurl"); domainURL != "" && !strings.Contains(source.Url, domainURL) {
+			continue
+		}
+		matching = append(matching, source)
+	}
+	return matching
+}
@@ -0,0 +1,186 @@
+package newsdatatest
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sicamois/newsdata"
+)
+
+// restrictedValue is the placeholder sent by the API for fields not available in the current plan.
+const restrictedValue = "ONLY AVAILABLE IN PROFESSIONAL AND CORPORATE PLANS"
+
+// articleToWire converts an article to the JSON representation sent by the API, without the excluded fields.
+//
+// The publication date is sent in loc if it is not nil (as for the timezone parameter), in the timezone of the article otherwise.
+func articleToWire(article newsdata.NewsArticle, excludedFields string, loc *time.Location) map[string]any {
+	pubDateTZ := article.PubDateTZ
+	if loc == nil {
+		loc = article.PubDate.Location()
+		if tz, err := time.LoadLocation(pubDateTZ); err == nil {
+			loc = tz
+		}
+	} else {
+		pubDateTZ = loc.String()
+	}
+	wire := map[string]any{
+		"article_id":      article.Id,
+		"title":           article.Title,
+		"link":            article.Link,
+		"keywords":        nilIfEmpty(article.Keywords),
+		"creator":         nilIfEmpty(article.Creator),
+		"video_url":       nilIfEmpty(article.VideoURL),
+		"description":     article.Description,
+		"content":         article.Content,
+		"pubDate":         formatDateTime(article.PubDate.Time, loc),
+		"pubDateTZ":       pubDateTZ,
+		"image_url":       nilIfEmpty(article.ImageURL),
+		"source_id":       article.SourceId,
+		"source_priority": article.SourcePriority,
+		"source_name":     article.SourceName,
+		"source_url":      article.SourceURL,
+		"source_icon":     article.SourceIconURL,
+		"language":        article.Language,
+		"country":         article.Countries,
+		"category":        article.Categories,
+		"ai_tag":          restrictedIfEmpty([]string(article.AiTags)),
+		"sentiment":       restrictedIfEmpty(article.Sentiment),
+		"sentiment_stats": restrictedIfEmpty(article.SentimentStats),
+		"ai_region":       restrictedIfEmpty([]string(article.AiRegions)),
+		"coin":            nilIfEmpty(article.Coin),
+		"symbol":          nilIfEmpty(article.Symbols),
+		"ai_org":          restrictedIfEmpty([]string(article.Organizations)),
+		"duplicate":       article.Duplicate,
+	}
+	for _, field := range strings.Split(excludedFields, ",") {
+		for key := range wire {
+			if strings.EqualFold(key, field) {
+				delete(wire, key)
+			}
+		}
+	}
+	return wire
+}
+
+// sourceToWire converts a source to the JSON representation sent by the API.
+func sourceToWire(source newsdata.Source) map[string]any {
+	return map[string]any{
+		"id":          source.Id,
+		"name":        source.Name,
+		"url":         source.Url,
+		"icon":        source.IconUrl,
+		"priority":    source.Priority,
+		"description": source.Description,
+		"category":    source.Categories,
+		"language":    source.Languages,
+		"country":     source.Countries,
+		"last_fetch":  formatDateTime(source.LastFetch.Time, time.UTC),
+	}
+}
+
+// formatDateTime formats a time in loc with the layout used by the API, or returns nil for the zero time.
+func formatDateTime(t time.Time, loc *time.Location) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t.In(loc).Format(time.DateTime)
+}
+
+// nilIfEmpty returns nil for zero values, which the API sends as null.
+func nilIfEmpty[T ~string | ~[]string](value T) any {
+	if len(value) == 0 {
+		return nil
+	}
+	return value
+}
+
+// restrictedIfEmpty returns the plan restriction placeholder for zero values, as the API does
+// for the fields not available in the free plan.
+func restrictedIfEmpty[T string | []string | newsdata.SentimentStats](value T) any {
+	var zero T
+	switch v := any(value).(type) {
+	case []string:
+		if len(v) == 0 {
+			return restrictedValue
+		}
+	default:
+		if any(value) == any(zero) {
+			return restrictedValue
+		}
+	}
+	return value
+}
+
+var (
+	sampleCountries  = []string{"us", "gb", "fr", "de", "jp"}
+	sampleLanguages  = []string{"en", "en", "fr", "de", "jp"}
+	sampleCategories = []string{"business", "technology", "politics", "sports", "science"}
+	sampleSentiments = []string{"positive", "neutral", "negative"}
+	sampleCoins      = []string{"btc", "eth", "usdt", "bnb", "sol"}
+	sampleSymbols    = []string{"AAPL", "MSFT", "GOOGL", "AMZN", "TSLA"}
+)
+
+// SampleArticles returns n deterministic articles, published every hour until 2025-01-01 00:00 UTC.
+//
+// Countries, languages, categories, sources, sentiments, coins and symbols cycle through a small set of values,
+// so that the articles can be used to test filters. Every third article has a full content, every
+// fourth has an image, and every tenth is a duplicate.
+func SampleArticles(n int) []newsdata.NewsArticle {
+	end := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	articles := make([]newsdata.NewsArticle, n)
+	for i := range articles {
+		source := i % len(sampleCountries)
+		category := sampleCategories[i%len(sampleCategories)]
+		article := newsdata.NewsArticle{
+			Id:             fmt.Sprintf("article-%04d", i),
+			Title:          fmt.Sprintf("Sample %s article %d", category, i),
+			Link:           fmt.Sprintf("https://source%d.example.com/articles/%d", source, i),
+			Keywords:       []string{category, "sample"},
+			Creator:        []string{fmt.Sprintf("Author %d", i%7)},
+			Description:    fmt.Sprintf("Description of sample %s article %d", category, i),
+			PubDate:        newsdata.DateTime{Time: end.Add(-time.Duration(i) * time.Hour)},
+			PubDateTZ:      "UTC",
+			SourceId:       fmt.Sprintf("source%d", source),
+			SourcePriority: (source + 1) * 1000,
+			SourceName:     fmt.Sprintf("Source %d", source),
+			SourceURL:      fmt.Sprintf("https://source%d.example.com", source),
+			SourceIconURL:  fmt.Sprintf("https://source%d.example.com/icon.png", source),
+			Language:       sampleLanguages[source],
+			Countries:      []string{sampleCountries[source]},
+			Categories:     []string{category},
+			Sentiment:      sampleSentiments[i%len(sampleSentiments)],
+			Coin:           []string{sampleCoins[i%len(sampleCoins)]},
+			Symbols:        []string{sampleSymbols[i%len(sampleSymbols)]},
+			Duplicate:      i%10 == 9,
+		}
+		if i%3 == 0 {
+			article.Content = fmt.Sprintf("Full content of sample %s article %d", category, i)
+		}
+		if i%4 == 0 {
+			article.ImageURL = fmt.Sprintf("https://source%d.example.com/images/%d.jpg", source, i)
+		}
+		articles[i] = article
+	}
+	return articles
+}
+
+// SampleSources returns the sources of the articles returned by SampleArticles.
+func SampleSources() []newsdata.Source {
+	sources := make([]newsdata.Source, len(sampleCountries))
+	for i := range sources {
+		sources[i] = newsdata.Source{
+			Id:          fmt.Sprintf("source%d", i),
+			Name:        fmt.Sprintf("Source %d", i),
+			Url:         fmt.Sprintf("https://source%d.example.com", i),
+			IconUrl:     fmt.Sprintf("https://source%d.example.com/icon.png", i),
+			Priority:    (i + 1) * 1000,
+			Description: fmt.Sprintf("Sample source %d", i),
+			Categories:  sampleCategories,
+			Languages:   []string{sampleLanguages[i]},
+			Countries:   []string{sampleCountries[i]},
+			LastFetch:   newsdata.DateTime{Time: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		}
+	}
+	return sources
+}
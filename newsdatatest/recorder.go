@@ -0,0 +1,267 @@
+package newsdatatest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Mode is the mode of a Recorder.
+type Mode int
+
+const (
+	// ModeReplay replays the recorded interactions, and fails the requests that were not recorded.
+	ModeReplay Mode = iota
+	// ModeRecord sends every request and records the interactions, replacing the existing ones.
+	ModeRecord
+	// ModeRecordMissing replays the recorded interactions, and sends and records the requests that were not recorded.
+	ModeRecordMissing
+)
+
+// ErrInteractionNotFound is returned in replay mode when a request does not match any recorded interaction.
+var ErrInteractionNotFound = errors.New("newsdatatest: interaction not found in cassette")
+
+// sensitiveKeys are the headers and query parameters scrubbed from the recorded interactions.
+var sensitiveKeys = []string{"X-Access-Key", "apikey"}
+
+// Interaction is a request/response pair recorded in a cassette file.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the part of a request used to match it with an interaction.
+type RecordedRequest struct {
+	Method   string `json:"method"`
+	Endpoint string `json:"endpoint"` // Endpoint called (e.g. "latest")
+	Query    string `json:"query"`    // Canonicalized query parameters, without the API key
+}
+
+// RecordedResponse is a recorded response.
+type RecordedResponse struct {
+	StatusCode int             `json:"status_code"`
+	Header     http.Header     `json:"header"`
+	Body       json.RawMessage `json:"body"`
+}
+
+// cassette is the content of a cassette file.
+type cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Recorder is an http.RoundTripper recording the requests made by the client and their responses
+// in a cassette file, and replaying them later, e.g. to turn a bug report with a real payload into
+// a regression test.
+//
+// Requests are matched on their method, endpoint and canonicalized query parameters. When the same
+// request was recorded several times, the interactions are replayed in order. The API key is never
+// recorded.
+//
+//	recorder, err := newsdatatest.NewRecorder("testdata/latest.json", newsdatatest.ModeRecordMissing, nil)
+//	client := newsdata.NewClient(newsdata.WithTransport(recorder))
+//	// Use client...
+//	err = recorder.Save()
+type Recorder struct {
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+
+	mu           sync.Mutex
+	interactions []Interaction
+	replayed     map[RecordedRequest]int
+	modified     bool
+}
+
+// NewRecorder creates a recorder using the cassette file at path.
+//
+// In ModeReplay and ModeRecordMissing, the cassette is loaded if the file exists. transport is used to
+// send the requests that are recorded; if nil, http.DefaultTransport is used.
+func NewRecorder(path string, mode Mode, transport http.RoundTripper) (*Recorder, error) {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	r := &Recorder{
+		path:      path,
+		mode:      mode,
+		transport: transport,
+		replayed:  make(map[RecordedRequest]int),
+	}
+	if mode == ModeRecord {
+		return r, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) && mode == ModeRecordMissing {
+			return r, nil
+		}
+		return nil, fmt.Errorf("newsdatatest: NewRecorder - error reading cassette - path: %s: %w", path, err)
+	}
+	var c cassette
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("newsdatatest: NewRecorder - error unmarshalling cassette - path: %s: %w", path, err)
+	}
+	r.interactions = c.Interactions
+	return r, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	key := recordedRequest(req)
+	if r.mode != ModeRecord {
+		if interaction, ok := r.find(key); ok {
+			return interaction.Response.toHTTP(req), nil
+		}
+		if r.mode == ModeReplay {
+			return nil, fmt.Errorf("%w: %s %s?%s", ErrInteractionNotFound, key.Method, key.Endpoint, key.Query)
+		}
+	}
+
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("newsdatatest: RoundTrip - error reading response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	recorded := RecordedResponse{
+		StatusCode: resp.StatusCode,
+		Header:     scrubHeader(resp.Header),
+		Body:       rawBody(body),
+	}
+	r.mu.Lock()
+	r.interactions = append(r.interactions, Interaction{Request: key, Response: recorded})
+	r.replayed[key]++
+	r.modified = true
+	r.mu.Unlock()
+	return resp, nil
+}
+
+// find returns the next interaction matching the request.
+func (r *Recorder) find(key RecordedRequest) (Interaction, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var matching []Interaction
+	for _, interaction := range r.interactions {
+		if interaction.Request == key {
+			matching = append(matching, interaction)
+		}
+	}
+	if len(matching) == 0 {
+		return Interaction{}, false
+	}
+	// Replay the interactions in order, then keep replaying the last one.
+	index := min(r.replayed[key], len(matching)-1)
+	r.replayed[key]++
+	return matching[index], true
+}
+
+// Save writes the recorded interactions to the cassette file, if new interactions were recorded.
+func (r *Recorder) Save() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.modified {
+		return nil
+	}
+	data, err := json.MarshalIndent(cassette{Interactions: r.interactions}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("newsdatatest: Save - error marshalling cassette: %w", err)
+	}
+	if err := os.WriteFile(r.path, data, 0644); err != nil {
+		return fmt.Errorf("newsdatatest: Save - error writing cassette - path: %s: %w", r.path, err)
+	}
+	r.modified = false
+	return nil
+}
+
+// Interactions returns the interactions recorded or loaded so far.
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.interactions)
+}
+
+// recordedRequest returns the matching key of a request.
+func recordedRequest(req *http.Request) RecordedRequest {
+	return RecordedRequest{
+		Method:   req.Method,
+		Endpoint: req.URL.Path[strings.LastIndex(req.URL.Path, "/")+1:],
+		Query:    canonicalQuery(req.URL.Query()),
+	}
+}
+
+// canonicalQuery encodes the query parameters sorted by key, with the comma-separated values sorted,
+// and without the sensitive parameters.
+func canonicalQuery(query url.Values) string {
+	canonical := url.Values{}
+	for key, values := range query {
+		if isSensitive(key) {
+			continue
+		}
+		for _, value := range values {
+			parts := strings.Split(value, ",")
+			slices.Sort(parts)
+			canonical.Add(key, strings.Join(parts, ","))
+		}
+	}
+	return canonical.Encode()
+}
+
+// scrubHeader returns a copy of the header without the sensitive values.
+func scrubHeader(header http.Header) http.Header {
+	scrubbed := header.Clone()
+	for key := range scrubbed {
+		if isSensitive(key) {
+			delete(scrubbed, key)
+		}
+	}
+	return scrubbed
+}
+
+func isSensitive(key string) bool {
+	return slices.ContainsFunc(sensitiveKeys, func(k string) bool { return strings.EqualFold(k, key) })
+}
+
+// rawBody returns the body as raw JSON if it is valid JSON, or as a JSON string otherwise.
+func rawBody(body []byte) json.RawMessage {
+	if json.Valid(body) {
+		return json.RawMessage(body)
+	}
+	quoted, _ := json.Marshal(string(body))
+	return json.RawMessage(quoted)
+}
+
+// toHTTP creates an http.Response from a recorded response.
+func (r RecordedResponse) toHTTP(req *http.Request) *http.Response {
+	body := []byte(r.Body)
+	var text string
+	if len(body) > 0 && body[0] == '"' && json.Unmarshal(body, &text) == nil {
+		body = []byte(text)
+	}
+	header := r.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode)),
+		StatusCode:    r.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,79 @@
+package newsdatatest_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sicamois/newsdata"
+	"github.com/sicamois/newsdata/newsdatatest"
+)
+
+func TestRecorder(t *testing.T) {
+	server := newServer(t)
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	recorder, err := newsdatatest.NewRecorder(path, newsdatatest.ModeRecord, nil)
+	if err != nil {
+		t.Fatalf("Error creating recorder: %v", err)
+	}
+	client := server.NewClient(newsdata.WithTransport(recorder))
+	recorded, err := client.LatestNews.Get(context.Background(), "", 15, newsdata.WithCountries("us", "fr"))
+	if err != nil {
+		t.Fatalf("Error fetching Latest News: %v", err)
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("Error saving cassette: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Error reading cassette: %v", err)
+	}
+	if strings.Contains(string(data), newsdatatest.APIKey) {
+		t.Fatalf("API key should be scrubbed from the cassette")
+	}
+
+	server.Close()
+	replayer, err := newsdatatest.NewRecorder(path, newsdatatest.ModeReplay, nil)
+	if err != nil {
+		t.Fatalf("Error creating replayer: %v", err)
+	}
+	client = newsdata.NewClient(newsdata.WithAPIKey("another-key"), newsdata.WithBaseURL(server.URL), newsdata.WithTransport(replayer))
+	// Countries are in another order: the query parameters are canonicalized.
+	replayed, err := client.LatestNews.Get(context.Background(), "", 15, newsdata.WithCountries("fr", "us"))
+	if err != nil {
+		t.Fatalf("Error replaying Latest News: %v", err)
+	}
+	if len(replayed) != len(recorded) || replayed[14].Id != recorded[14].Id {
+		t.Fatalf("Replayed articles do not match the recorded ones")
+	}
+
+	// A request missing from the cassette is not retried.
+	calls := 0
+	transport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		return replayer.RoundTrip(req)
+	})
+	client = newsdata.NewClient(newsdata.WithAPIKey("another-key"), newsdata.WithBaseURL(server.URL), newsdata.WithTransport(transport),
+		newsdata.WithLogWriter(io.Discard), newsdata.WithRetryPolicy(newsdata.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}))
+	_, err = client.LatestNews.Get(context.Background(), "", 15, newsdata.WithCountries("gb"))
+	if !errors.Is(err, newsdatatest.ErrInteractionNotFound) {
+		t.Fatalf("Error should be ErrInteractionNotFound: %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("Invalid number of calls: %d - should be 1", calls)
+	}
+}
+
+// roundTripperFunc is an http.RoundTripper calling a function.
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
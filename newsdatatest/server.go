@@ -0,0 +1,342 @@
+// Package newsdatatest provides a fake NewsData API server for testing code using the newsdata client offline.
+//
+// The server implements the latest, archive, crypto, market and sources endpoints, filters fixtures according
+// to the request parameters, paginates the results, and can inject errors and latency.
+//
+//	server := newsdatatest.NewServer()
+//	defer server.Close()
+//	server.AddArticles(newsdatatest.EndpointLatest, newsdatatest.SampleArticles(30)...)
+//	client := server.NewClient()
+//	articles, err := client.LatestNews.Get(ctx, "", 0, newsdata.WithCountries("us"))
+package newsdatatest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sicamois/newsdata"
+)
+
+// Endpoints implemented by the fake server.
+const (
+	EndpointLatest  = "latest"
+	EndpointArchive = "archive"
+	EndpointCrypto  = "crypto"
+	EndpointMarket  = "market"
+	EndpointSources = "sources"
+)
+
+// APIKey is the API key accepted by the server, unless another one is set with Server.SetAPIKey.
+const APIKey = "newsdatatest-api-key"
+
+const (
+	defaultPageSize = 10 // Page size when the size parameter is not set
+	maxPageSize     = 50 // Maximum page size
+)
+
+// Fault describes an error or a latency injected in the responses of the server.
+type Fault struct {
+	StatusCode int           // HTTP status code of the error response. 0 means no error (latency only).
+	Code       string        // API error code (e.g. "RateLimitExceeded")
+	Message    string        // API error message
+	RetryAfter time.Duration // Value of the Retry-After header, if any
+	Latency    time.Duration // Delay before responding
+	Times      int           // Number of requests affected by the fault. 0 means 1.
+}
+
+// RateLimitFault is a 429 fault, as returned by the API when the rate limit is exceeded.
+func RateLimitFault(times int, retryAfter time.Duration) Fault {
+	return Fault{
+		StatusCode: http.StatusTooManyRequests,
+		Code:       "RateLimitExceeded",
+		Message:    "Rate limit exceeded",
+		RetryAfter: retryAfter,
+		Times:      times,
+	}
+}
+
+// Request is a request received by the server.
+type Request struct {
+	Endpoint string     // Endpoint called (e.g. "latest")
+	Query    url.Values // Query parameters
+	APIKey   string     // Value of the X-ACCESS-KEY header
+}
+
+type fault struct {
+	Fault
+	endpoint  string
+	remaining int
+}
+
+// Server is a fake NewsData API server, running on a local httptest.Server.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	apiKey   string
+	now      func() time.Time
+	latency  time.Duration
+	articles map[string][]newsdata.NewsArticle
+	sources  []newsdata.Source
+	faults   []*fault
+	requests []Request
+	credits  int
+}
+
+// NewServer starts a fake NewsData API server without any fixture.
+//
+// The caller should call Close when finished, to shut it down.
+func NewServer() *Server {
+	s := &Server{
+		apiKey:   APIKey,
+		now:      time.Now,
+		articles: make(map[string][]newsdata.NewsArticle),
+		credits:  -1,
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// NewClient returns a newsdata client configured to use the server.
+//
+// Additional options are applied after the base URL and API key options.
+func (s *Server) NewClient(opts ...newsdata.NewsDataClientOption) *newsdata.NewsDataClient {
+	s.mu.Lock()
+	apiKey := s.apiKey
+	s.mu.Unlock()
+	return newsdata.NewClient(append([]newsdata.NewsDataClientOption{newsdata.WithBaseURL(s.URL), newsdata.WithAPIKey(apiKey)}, opts...)...)
+}
+
+// SetAPIKey sets the API key accepted by the server. Requests with another key fail with a 401 error.
+// An empty key accepts any key.
+func (s *Server) SetAPIKey(apiKey string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.apiKey = apiKey
+}
+
+// SetNow sets the clock used for the timeframe parameter. By default, the server uses time.Now.
+func (s *Server) SetNow(now func() time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.now = now
+}
+
+// SetLatency sets a delay applied to every response.
+func (s *Server) SetLatency(latency time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency = latency
+}
+
+// SetCredits sets the number of credits remaining, reported in the rate limit headers and decremented
+// by each successful request. Once no credit remains, requests fail with a 429 error. -1 (the default)
+// means unlimited credits, without rate limit headers.
+func (s *Server) SetCredits(credits int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.credits = credits
+}
+
+// AddArticles adds articles served by an endpoint (EndpointLatest, EndpointArchive, EndpointCrypto or EndpointMarket).
+func (s *Server) AddArticles(endpoint string, articles ...newsdata.NewsArticle) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.articles[endpoint] = append(s.articles[endpoint], articles...)
+}
+
+// AddSources adds sources served by the sources endpoint.
+func (s *Server) AddSources(sources ...newsdata.Source) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sources = append(s.sources, sources...)
+}
+
+// InjectFault injects a fault in the next requests to an endpoint. An empty endpoint matches every endpoint.
+//
+// Faults are applied in the order they were injected.
+func (s *Server) InjectFault(endpoint string, f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	times := f.Times
+	if times <= 0 {
+		times = 1
+	}
+	s.faults = append(s.faults, &fault{Fault: f, endpoint: endpoint, remaining: times})
+}
+
+// Requests returns the requests received by the server so far.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.requests)
+}
+
+// nextFault returns the fault to apply to a request to endpoint, if any. It must be called with mu held.
+func (s *Server) nextFault(endpoint string) *Fault {
+	for i, f := range s.faults {
+		if f.endpoint != "" && f.endpoint != endpoint {
+			continue
+		}
+		f.remaining--
+		if f.remaining <= 0 {
+			s.faults = slices.Delete(s.faults, i, i+1)
+		}
+		return &f.Fault
+	}
+	return nil
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	endpoint := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+	query := r.URL.Query()
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{Endpoint: endpoint, Query: query, APIKey: r.Header.Get("X-ACCESS-KEY")})
+	apiKey := s.apiKey
+	now := s.now()
+	latency := s.latency
+	f := s.nextFault(endpoint)
+	s.mu.Unlock()
+
+	if f != nil {
+		latency += f.Latency
+	}
+	if latency > 0 {
+		select {
+		case <-time.After(latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+	if f != nil && f.StatusCode != 0 {
+		if f.RetryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(f.RetryAfter.Round(time.Second)/time.Second)))
+		}
+		writeError(w, f.StatusCode, f.Code, f.Message)
+		return
+	}
+	if apiKey != "" && r.Header.Get("X-ACCESS-KEY") != apiKey {
+		writeError(w, http.StatusUnauthorized, "Unauthorized", "API key invalid")
+		return
+	}
+	if !s.spendCredit(w) {
+		writeError(w, http.StatusTooManyRequests, "RateLimitExceeded", "Rate limit exceeded")
+		return
+	}
+
+	switch endpoint {
+	case EndpointLatest, EndpointArchive, EndpointCrypto, EndpointMarket:
+		s.handleNews(w, endpoint, query, now)
+	case EndpointSources:
+		s.handleSources(w, query)
+	default:
+		writeError(w, http.StatusNotFound, "NotFound", fmt.Sprintf("Endpoint %s not found", endpoint))
+	}
+}
+
+// spendCredit spends a credit and writes the rate limit headers. It returns false if no credit remains.
+func (s *Server) spendCredit(w http.ResponseWriter) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.credits < 0 {
+		return true
+	}
+	if s.credits == 0 {
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		return false
+	}
+	s.credits--
+	w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(s.credits))
+	return true
+}
+
+func (s *Server) handleNews(w http.ResponseWriter, endpoint string, query url.Values, now time.Time) {
+	size := defaultPageSize
+	if value := query.Get("size"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > maxPageSize {
+			writeError(w, http.StatusUnprocessableEntity, "UnsupportedParameter", "size must be between 1 and 50")
+			return
+		}
+		size = n
+	}
+	offset := 0
+	if value := query.Get("page"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			writeError(w, http.StatusUnprocessableEntity, "UnsupportedParameter", "invalid page token")
+			return
+		}
+		offset = n
+	}
+
+	var loc *time.Location
+	if timezone := query.Get("timezone"); timezone != "" {
+		var err error
+		if loc, err = time.LoadLocation(timezone); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, "UnsupportedParameter", fmt.Sprintf("timezone %s is not supported", timezone))
+			return
+		}
+	}
+
+	s.mu.Lock()
+	articles := filterArticles(s.articles[endpoint], query, now)
+	s.mu.Unlock()
+
+	end := min(offset+size, len(articles))
+	results := make([]map[string]any, 0, max(end-offset, 0))
+	for i := offset; i < end; i++ {
+		results = append(results, articleToWire(articles[i], query.Get("excludefield"), loc))
+	}
+	var nextPage any
+	if end < len(articles) {
+		nextPage = strconv.Itoa(end)
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"status":       "success",
+		"totalResults": len(articles),
+		"results":      results,
+		"nextPage":     nextPage,
+	})
+}
+
+func (s *Server) handleSources(w http.ResponseWriter, query url.Values) {
+	s.mu.Lock()
+	sources := filterSources(s.sources, query)
+	s.mu.Unlock()
+
+	results := make([]map[string]any, 0, len(sources))
+	for _, source := range sources {
+		results = append(results, sourceToWire(source))
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"status":       "success",
+		"totalResults": len(results),
+		"results":      results,
+	})
+}
+
+func writeError(w http.ResponseWriter, statusCode int, code string, message string) {
+	writeJSON(w, statusCode, map[string]any{
+		"status": "error",
+		"results": map[string]string{
+			"message": message,
+			"code":    code,
+		},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(body)
+}
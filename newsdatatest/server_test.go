@@ -0,0 +1,100 @@
+package newsdatatest_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/sicamois/newsdata"
+	"github.com/sicamois/newsdata/newsdatatest"
+)
+
+func newServer(t *testing.T) *newsdatatest.Server {
+	server := newsdatatest.NewServer()
+	t.Cleanup(server.Close)
+	server.AddArticles(newsdatatest.EndpointLatest, newsdatatest.SampleArticles(45)...)
+	server.AddArticles(newsdatatest.EndpointCrypto, newsdatatest.SampleArticles(20)...)
+	server.AddSources(newsdatatest.SampleSources()...)
+	return server
+}
+
+func TestPagination(t *testing.T) {
+	server := newServer(t)
+	client := server.NewClient()
+
+	articles, err := client.LatestNews.Get(context.Background(), "", 0)
+	if err != nil {
+		t.Fatalf("Error fetching Latest News: %v", err)
+	}
+	if len(articles) != 45 {
+		t.Fatalf("Invalid number of articles: %d - should be 45", len(articles))
+	}
+	for i := 1; i < len(articles); i++ {
+		if articles[i].PubDate.After(articles[i-1].PubDate.Time) {
+			t.Fatalf("Articles should be sorted by publication date, most recent first")
+		}
+	}
+	if requests := server.Requests(); len(requests) != 5 {
+		t.Fatalf("Invalid number of requests: %d - should be 5", len(requests))
+	}
+}
+
+func TestFilters(t *testing.T) {
+	server := newServer(t)
+	client := server.NewClient()
+
+	articles, err := client.LatestNews.Get(context.Background(), "technology", 0, newsdata.WithCountries("gb"), newsdata.WithSize(50))
+	if err != nil {
+		t.Fatalf("Error fetching Latest News: %v", err)
+	}
+	// Technology is every 5th article starting at 1, and gb is every 5th article starting at 1.
+	if len(articles) != 9 {
+		t.Fatalf("Invalid number of articles: %d - should be 9", len(articles))
+	}
+	for _, article := range articles {
+		if article.Countries[0] != "gb" || article.Categories[0] != "technology" {
+			t.Fatalf("Article does not match the filters: %+v", article)
+		}
+	}
+
+	articles, err = client.CryptoNews.Get(context.Background(), "", 0, newsdata.WithCoins("btc", "eth"), newsdata.WithRemoveDuplicates())
+	if err != nil {
+		t.Fatalf("Error fetching Crypto News: %v", err)
+	}
+	if len(articles) != 8 {
+		t.Fatalf("Invalid number of articles: %d - should be 8", len(articles))
+	}
+
+	sources, err := client.Sources.Get(context.Background(), newsdata.WithLanguage("fr"))
+	if err != nil {
+		t.Fatalf("Error fetching Sources: %v", err)
+	}
+	if len(sources) != 1 || sources[0].Id != "source2" {
+		t.Fatalf("Invalid sources: %v", sources)
+	}
+}
+
+func TestFaults(t *testing.T) {
+	server := newServer(t)
+	server.InjectFault(newsdatatest.EndpointLatest, newsdatatest.RateLimitFault(2, time.Second))
+	client := server.NewClient(newsdata.WithLogWriter(io.Discard))
+
+	_, err := client.LatestNews.Get(context.Background(), "", 10)
+	var apiErr *newsdata.APIError
+	if !errors.Is(err, newsdata.ErrRateLimited) || !errors.As(err, &apiErr) || apiErr.RetryAfter != time.Second {
+		t.Fatalf("Error should be ErrRateLimited with Retry-After: %v", err)
+	}
+
+	retryClient := server.NewClient(newsdata.WithLogWriter(io.Discard), newsdata.WithRetryPolicy(newsdata.RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond}))
+	server.InjectFault(newsdatatest.EndpointLatest, newsdatatest.Fault{Latency: 10 * time.Millisecond})
+	if _, err := retryClient.LatestNews.Get(context.Background(), "", 10); err != nil {
+		t.Fatalf("Error fetching Latest News after the faults: %v", err)
+	}
+
+	server.SetAPIKey("another-key")
+	if _, err := client.LatestNews.Get(context.Background(), "", 10); !errors.Is(err, newsdata.ErrUnauthorized) {
+		t.Fatalf("Error should be ErrUnauthorized: %v", err)
+	}
+}
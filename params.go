@@ -3,6 +3,7 @@ package newsdata
 import (
 	"fmt"
 	"log/slog"
+	"regexp"
 	"slices"
 	"strings"
 	"time"
@@ -16,6 +17,7 @@ const (
 	endpointLatestNews  endpoint = "latest"  // Endpoint for fetching latest news
 	endpointNewsArchive endpoint = "archive" // Endpoint for accessing news archives
 	endpointCoinNews    endpoint = "crypto"  // Endpoint for cryptocurrency news
+	endpointMarketNews  endpoint = "market"  // Endpoint for market and financial news
 	endpointSources     endpoint = "sources" // Endpoint for news sources information
 )
 
@@ -28,6 +30,8 @@ func (e endpoint) String() string {
 		return "News Archive"
 	case endpointCoinNews:
 		return "Crypto News"
+	case endpointMarketNews:
+		return "Market News"
 	case endpointSources:
 		return "Sources"
 	}
@@ -48,37 +52,153 @@ func (p requestParams) String() string {
 	return fmt.Sprintf("{%s}", strings.Join(params, ", "))
 }
 
+// paramValidator collects the issues found while applying the request parameters.
+//
+// Every issue is logged with the client logger. In strict mode, the issues are returned as a *ParamError.
+type paramValidator struct {
+	logger *slog.Logger
+	issues []ParamIssue
+}
+
+// warn logs and records an invalid parameter value.
+func (v *paramValidator) warn(param string, value string, msg string) {
+	v.logger.Warn(msg)
+	v.record(param, value, msg)
+}
+
+// error logs at error level and records an invalid parameter value.
+func (v *paramValidator) error(param string, value string, msg string) {
+	v.logger.Error(msg)
+	v.record(param, value, msg)
+}
+
+func (v *paramValidator) record(param string, value string, msg string) {
+	v.issues = append(v.issues, ParamIssue{
+		Param:  param,
+		Value:  value,
+		Reason: strings.TrimPrefix(msg, "newsdata: "),
+	})
+}
+
 // newRequestParams creates a new set of request parameters with the given query and options.
 // It validates and processes the parameters based on the endpoint type.
-func newRequestParams[T NewsRequestParams | SourceRequestParams](query string, logger *slog.Logger, endpoint endpoint, params ...T) requestParams {
+//
+// Invalid values are logged and dropped or truncated. If strict is true, it returns a *ParamError
+// listing every invalid value instead.
+func newRequestParams[T requestParam](query string, logger *slog.Logger, strict bool, endpoint endpoint, params ...T) (requestParams, error) {
+	v := &paramValidator{logger: logger}
 	p := requestParams{}
 	if query != "" {
 		if endpoint != endpointSources {
 			p["q"] = query
 		} else {
-			logger.Warn("newsdata: query is not supported for sources")
+			v.warn("q", query, "newsdata: query is not supported for sources")
 		}
 	}
 	for _, param := range params {
-		param(p, endpoint, logger)
+		param.apply(p, endpoint, v)
 	}
-	return p
+	if strict && len(v.issues) > 0 {
+		return nil, &ParamError{Endpoint: string(endpoint), Issues: v.issues}
+	}
+	return p, nil
+}
+
+// requestParam is implemented by every request parameter type.
+type requestParam interface {
+	apply(p requestParams, endpoint endpoint, v *paramValidator)
+}
+
+// LatestNewsParam is a request parameter accepted by the latest news endpoint.
+//
+// It is implemented by NewsRequestParams and LatestCryptoParams.
+type LatestNewsParam interface {
+	requestParam
+	latestNewsParam()
+}
+
+// NewsArchiveParam is a request parameter accepted by the news archive endpoint.
+//
+// It is implemented by NewsRequestParams and ArchiveCryptoParams.
+type NewsArchiveParam interface {
+	requestParam
+	newsArchiveParam()
 }
 
-type NewsRequestParams func(p requestParams, endpoint endpoint, logger *slog.Logger)
+// CryptoNewsParam is a request parameter accepted by the crypto news endpoint.
+//
+// It is implemented by NewsRequestParams, LatestCryptoParams, ArchiveCryptoParams and CryptoParams.
+type CryptoNewsParam interface {
+	requestParam
+	cryptoNewsParam()
+}
+
+// MarketNewsParam is a request parameter accepted by the market news endpoint.
+//
+// It is implemented by NewsRequestParams, LatestCryptoParams and MarketParams.
+type MarketNewsParam interface {
+	requestParam
+	marketNewsParam()
+}
+
+// NewsRequestParams is a request parameter supported by all the news endpoints.
+type NewsRequestParams func(p requestParams, endpoint endpoint, v *paramValidator)
+
+func (f NewsRequestParams) apply(p requestParams, endpoint endpoint, v *paramValidator) {
+	f(p, endpoint, v)
+}
+func (NewsRequestParams) latestNewsParam()  {}
+func (NewsRequestParams) newsArchiveParam() {}
+func (NewsRequestParams) cryptoNewsParam()  {}
+func (NewsRequestParams) marketNewsParam()  {}
+
+// LatestCryptoParams is a request parameter supported only by the latest news, crypto news and market news endpoints.
+type LatestCryptoParams func(p requestParams, endpoint endpoint, v *paramValidator)
+
+func (f LatestCryptoParams) apply(p requestParams, endpoint endpoint, v *paramValidator) {
+	f(p, endpoint, v)
+}
+func (LatestCryptoParams) latestNewsParam() {}
+func (LatestCryptoParams) cryptoNewsParam() {}
+func (LatestCryptoParams) marketNewsParam() {}
+
+// ArchiveCryptoParams is a request parameter supported only by the news archive and crypto news endpoints.
+type ArchiveCryptoParams func(p requestParams, endpoint endpoint, v *paramValidator)
+
+func (f ArchiveCryptoParams) apply(p requestParams, endpoint endpoint, v *paramValidator) {
+	f(p, endpoint, v)
+}
+func (ArchiveCryptoParams) newsArchiveParam() {}
+func (ArchiveCryptoParams) cryptoNewsParam()  {}
+
+// CryptoParams is a request parameter supported only by the crypto news endpoint.
+type CryptoParams func(p requestParams, endpoint endpoint, v *paramValidator)
+
+func (f CryptoParams) apply(p requestParams, endpoint endpoint, v *paramValidator) {
+	f(p, endpoint, v)
+}
+func (CryptoParams) cryptoNewsParam() {}
+
+// MarketParams is a request parameter supported only by the market news endpoint.
+type MarketParams func(p requestParams, endpoint endpoint, v *paramValidator)
+
+func (f MarketParams) apply(p requestParams, endpoint endpoint, v *paramValidator) {
+	f(p, endpoint, v)
+}
+func (MarketParams) marketNewsParam() {}
 
 // WithQueryInTitle adds a query to search in article titles.
 //
 // QueryInTitle can't be used with Query or QueryInMeta parameter in the same query.
 func WithQueryInTitle(query string) NewsRequestParams {
-	return func(p requestParams, endpoint endpoint, logger *slog.Logger) {
+	return func(p requestParams, endpoint endpoint, v *paramValidator) {
 		if p["qInMeta"] != "" || p["q"] != "" {
-			logger.Error("newsdata: QueryInTitle can't be used with Query or QueryInMeta. Only QueryInTitle will be used.")
+			v.error("qInTitle", query, "newsdata: QueryInTitle can't be used with Query or QueryInMeta. Only QueryInTitle will be used.")
 			delete(p, "qInMeta")
 			delete(p, "q")
 		}
 		if len(query) > 512 {
-			logger.Warn("newsdata: query length is greater than 512, truncating to 512")
+			v.warn("qInTitle", query, "newsdata: query length is greater than 512, truncating to 512")
 			query = query[:512]
 		}
 		p["qInTitle"] = query
@@ -89,14 +209,14 @@ func WithQueryInTitle(query string) NewsRequestParams {
 //
 // QueryInMetadata can't be used with Query or QueryInTitle parameter in the same query.
 func WithQueryInMetadata(query string) NewsRequestParams {
-	return func(p requestParams, endpoint endpoint, logger *slog.Logger) {
+	return func(p requestParams, endpoint endpoint, v *paramValidator) {
 		if p["qInTitle"] != "" || p["q"] != "" {
-			logger.Error("newsdata: QueryInMetadata can't be used with Query or QueryInTitle. Only QueryInMetadata will be used.")
+			v.error("qInMeta", query, "newsdata: QueryInMetadata can't be used with Query or QueryInTitle. Only QueryInMetadata will be used.")
 			delete(p, "qInTitle")
 			delete(p, "q")
 		}
 		if len(query) > 512 {
-			logger.Warn("newsdata: query length is greater than 512, truncating to 512")
+			v.warn("qInMeta", query, "newsdata: query length is greater than 512, truncating to 512")
 			query = query[:512]
 		}
 		p["qInMeta"] = query
@@ -105,18 +225,18 @@ func WithQueryInMetadata(query string) NewsRequestParams {
 
 // validateCategories validates and filters the provided category list.
 // It ensures only allowed categories are included and limits the total to 5.
-func validateCategories(categories []string, logger *slog.Logger) []string {
+func validateCategories(param string, categories []string, v *paramValidator) []string {
 	safeCategories := make([]string, 0, len(categories))
 	for _, category := range categories {
 		if slices.Contains(allowedCategories, category) {
 			safeCategories = append(safeCategories, category)
 		} else {
-			logger.Warn(fmt.Sprintf("newsdata: category \"%s\" is not allowed", category))
+			v.warn(param, category, fmt.Sprintf("newsdata: category \"%s\" is not allowed", category))
 		}
 	}
 	if len(safeCategories) > 5 {
-		logger.Warn("newsdata: categories length is greater than 5, truncating to 5")
-		categories = categories[:5]
+		v.warn(param, strings.Join(categories, ","), "newsdata: categories length is greater than 5, truncating to 5")
+		safeCategories = safeCategories[:5]
 	}
 	return safeCategories
 }
@@ -125,16 +245,16 @@ func validateCategories(categories []string, logger *slog.Logger) []string {
 //
 // You can use either the 'categories' parameter to include specific categories or the 'excludecategories' parameter to exclude them, but not both simultaneously.
 func WithCategories(categories ...string) NewsRequestParams {
-	return func(p requestParams, endpoint endpoint, logger *slog.Logger) {
+	return func(p requestParams, endpoint endpoint, v *paramValidator) {
 		if len(categories) == 0 {
 			return
 		}
 		if p["excludecategory"] != "" {
-			logger.Error("newsdata: categories and excluded categories cannot be used together")
+			v.error("category", strings.Join(categories, ","), "newsdata: categories and excluded categories cannot be used together")
 			return
 		}
-		safeCategories := validateCategories(categories, logger)
-		if safeCategories != nil {
+		safeCategories := validateCategories("category", categories, v)
+		if len(safeCategories) > 0 {
 			p["category"] = strings.Join(safeCategories, ",")
 		}
 	}
@@ -144,16 +264,16 @@ func WithCategories(categories ...string) NewsRequestParams {
 //
 // You can use either the 'category' parameter to include specific categories or the 'excludecategory' parameter to exclude them, but not both simultaneously.
 func WithCategoriesExlucded(categories ...string) NewsRequestParams {
-	return func(p requestParams, endpoint endpoint, logger *slog.Logger) {
+	return func(p requestParams, endpoint endpoint, v *paramValidator) {
 		if len(categories) == 0 {
 			return
 		}
 		if p["category"] != "" {
-			logger.Error("newsdata: categories and excluded categories cannot be used together")
+			v.error("excludecategory", strings.Join(categories, ","), "newsdata: categories and excluded categories cannot be used together")
 			return
 		}
-		safeCategories := validateCategories(categories, logger)
-		if safeCategories != nil {
+		safeCategories := validateCategories("excludecategory", categories, v)
+		if len(safeCategories) > 0 {
 			p["excludecategory"] = strings.Join(safeCategories, ",")
 		}
 	}
@@ -161,18 +281,18 @@ func WithCategoriesExlucded(categories ...string) NewsRequestParams {
 
 // validateCountries validates and filters the provided country codes.
 // It ensures only allowed country codes are included and limits the total to 5.
-func validateCountries(countries []string, logger *slog.Logger) []string {
+func validateCountries(countries []string, v *paramValidator) []string {
 	safeCountries := make([]string, 0, len(countries))
 	for _, country := range countries {
 		if slices.Contains(allowedCountries, country) {
 			safeCountries = append(safeCountries, country)
 		} else {
-			logger.Warn(fmt.Sprintf("newsdata: country \"%s\" is not allowed", country))
+			v.warn("country", country, fmt.Sprintf("newsdata: country \"%s\" is not allowed", country))
 		}
 	}
 	if len(safeCountries) > 5 {
-		logger.Warn("newsdata: countries length is greater than 5, truncating to 5")
-		countries = countries[:5]
+		v.warn("country", strings.Join(countries, ","), "newsdata: countries length is greater than 5, truncating to 5")
+		safeCountries = safeCountries[:5]
 	}
 	return safeCountries
 }
@@ -181,31 +301,31 @@ func validateCountries(countries []string, logger *slog.Logger) []string {
 //
 // It accepts up to 5 country codes and validates them against allowed values.
 func WithCountries(countries ...string) NewsRequestParams {
-	return func(p requestParams, endpoint endpoint, logger *slog.Logger) {
+	return func(p requestParams, endpoint endpoint, v *paramValidator) {
 		if len(countries) == 0 {
 			return
 		}
 
-		safeCountries := validateCountries(countries, logger)
-		if safeCountries != nil {
+		safeCountries := validateCountries(countries, v)
+		if len(safeCountries) > 0 {
 			p["country"] = strings.Join(safeCountries, ",")
 		}
 	}
 }
 
 // validateLanguages filters and validates the provided language codes.
-func validateLanguages(languages []string, logger *slog.Logger) []string {
+func validateLanguages(languages []string, v *paramValidator) []string {
 	safeLanguages := make([]string, 0, len(languages))
 	for _, language := range languages {
 		if slices.Contains(allowedLanguages, language) {
 			safeLanguages = append(safeLanguages, language)
 		} else {
-			logger.Warn(fmt.Sprintf("newsdata: language \"%s\" is not allowed", language))
+			v.warn("language", language, fmt.Sprintf("newsdata: language \"%s\" is not allowed", language))
 		}
 	}
 	if len(safeLanguages) > 5 {
-		logger.Warn("newsdata: languages length is greater than 5, truncating to 5")
-		languages = languages[:5]
+		v.warn("language", strings.Join(languages, ","), "newsdata: languages length is greater than 5, truncating to 5")
+		safeLanguages = safeLanguages[:5]
 	}
 	return safeLanguages
 }
@@ -214,12 +334,12 @@ func validateLanguages(languages []string, logger *slog.Logger) []string {
 //
 // Please refer to [newsdata.io docs](https://newsdata.io/documentation/#latest-news) for the list of allowed languages.
 func WithLanguages(languages ...string) NewsRequestParams {
-	return func(p requestParams, endpoint endpoint, logger *slog.Logger) {
+	return func(p requestParams, endpoint endpoint, v *paramValidator) {
 		if len(languages) == 0 {
 			return
 		}
-		safeLanguages := validateLanguages(languages, logger)
-		if safeLanguages != nil {
+		safeLanguages := validateLanguages(languages, v)
+		if len(safeLanguages) > 0 {
 			p["language"] = strings.Join(safeLanguages, ",")
 		}
 	}
@@ -229,12 +349,12 @@ func WithLanguages(languages ...string) NewsRequestParams {
 //
 // Please refer to [newsdata.io docs](https://newsdata.io/documentation/#latest-news) for the list of allowed domains.
 func WithDomains(domains ...string) NewsRequestParams {
-	return func(p requestParams, endpoint endpoint, logger *slog.Logger) {
+	return func(p requestParams, endpoint endpoint, v *paramValidator) {
 		if len(domains) == 0 {
 			return
 		}
 		if len(domains) > 5 {
-			logger.Warn("newsdata: domains length is greater than 5, truncating to 5")
+			v.warn("domain", strings.Join(domains, ","), "newsdata: domains length is greater than 5, truncating to 5")
 			domains = domains[:5]
 		}
 		p["domain"] = strings.Join(domains, ",")
@@ -245,12 +365,12 @@ func WithDomains(domains ...string) NewsRequestParams {
 //
 // Please refer to [newsdata.io docs](https://newsdata.io/documentation/#latest-news) for the list of allowed domains.
 func WithDomainExcluded(domains ...string) NewsRequestParams {
-	return func(p requestParams, endpoint endpoint, logger *slog.Logger) {
+	return func(p requestParams, endpoint endpoint, v *paramValidator) {
 		if len(domains) == 0 {
 			return
 		}
 		if len(domains) > 5 {
-			logger.Warn("newsdata: domains length is greater than 5, truncating to 5")
+			v.warn("excludedomain", strings.Join(domains, ","), "newsdata: domains length is greater than 5, truncating to 5")
 			domains = domains[:5]
 		}
 		p["excludedomain"] = strings.Join(domains, ",")
@@ -259,9 +379,9 @@ func WithDomainExcluded(domains ...string) NewsRequestParams {
 
 // validatePriorityDomain validates if the provided domain is an allowed priority domain.
 // Returns true if the domain is valid, false otherwise.
-func validatePriorityDomain(priorityDomain string, logger *slog.Logger) bool {
+func validatePriorityDomain(priorityDomain string, v *paramValidator) bool {
 	if !slices.Contains(allowedPriorityDomains, priorityDomain) {
-		logger.Warn(fmt.Sprintf("newsdata: priority domain \"%s\" is not allowed", priorityDomain))
+		v.warn("prioritydomain", priorityDomain, fmt.Sprintf("newsdata: priority domain \"%s\" is not allowed", priorityDomain))
 		return false
 	}
 	return true
@@ -271,12 +391,12 @@ func validatePriorityDomain(priorityDomain string, logger *slog.Logger) bool {
 //
 // It accepts up to 5 domain URLs for filtering news sources.
 func WithDomainUrls(domainUrls ...string) NewsRequestParams {
-	return func(p requestParams, endpoint endpoint, logger *slog.Logger) {
+	return func(p requestParams, endpoint endpoint, v *paramValidator) {
 		if len(domainUrls) == 0 {
 			return
 		}
 		if len(domainUrls) > 5 {
-			logger.Warn("newsdata: domain URLs length is greater than 5, truncating to 5")
+			v.warn("domainurl", strings.Join(domainUrls, ","), "newsdata: domain URLs length is greater than 5, truncating to 5")
 			domainUrls = domainUrls[:5]
 		}
 		p["domainurl"] = strings.Join(domainUrls, ",")
@@ -285,11 +405,11 @@ func WithDomainUrls(domainUrls ...string) NewsRequestParams {
 
 // WithSourcePriorityDomain sets a priority domain for the article request
 func WithSourcePriorityDomain(priorityDomain string) NewsRequestParams {
-	return func(p requestParams, endpoint endpoint, logger *slog.Logger) {
+	return func(p requestParams, endpoint endpoint, v *paramValidator) {
 		if priorityDomain == "" {
 			return
 		}
-		if !validatePriorityDomain(priorityDomain, logger) {
+		if !validatePriorityDomain(priorityDomain, v) {
 			return
 		}
 		p["prioritydomain"] = priorityDomain
@@ -298,7 +418,7 @@ func WithSourcePriorityDomain(priorityDomain string) NewsRequestParams {
 
 // WithFieldsExcluded specifies fields to exclude from the response.
 func WithFieldsExcluded(fields ...string) NewsRequestParams {
-	return func(p requestParams, endpoint endpoint, logger *slog.Logger) {
+	return func(p requestParams, endpoint endpoint, v *paramValidator) {
 		if len(fields) == 0 {
 			return
 		}
@@ -309,8 +429,10 @@ func WithFieldsExcluded(fields ...string) NewsRequestParams {
 // WithTimezone Search the news articles for a specific timezone.
 //
 // Please refer to [timezones](https://en.wikipedia.org/wiki/List_of_tz_database_time_zones) for the list of allowed timezones.
+//
+// The API then sends the publication dates in this timezone: they are decoded in this location, and PubDateTZ is set to timezone.
 func WithTimezone(timezone string) NewsRequestParams {
-	return func(p requestParams, endpoint endpoint, logger *slog.Logger) {
+	return func(p requestParams, endpoint endpoint, v *paramValidator) {
 		if timezone == "" {
 			return
 		}
@@ -320,107 +442,100 @@ func WithTimezone(timezone string) NewsRequestParams {
 
 // WithOnlyFullContent requests only articles with a full content.
 func WithOnlyFullContent() NewsRequestParams {
-	return func(p requestParams, endpoint endpoint, logger *slog.Logger) {
+	return func(p requestParams, endpoint endpoint, v *paramValidator) {
 		p["full_content"] = "1"
 	}
 }
 
 // WithNoFullContent requests only articles without a full content.
 func WithNoFullContent() NewsRequestParams {
-	return func(p requestParams, endpoint endpoint, logger *slog.Logger) {
+	return func(p requestParams, endpoint endpoint, v *paramValidator) {
 		p["full_content"] = "0"
 	}
 }
 
 // WithOnlyImage requests only articles with an image.
 func WithOnlyImage() NewsRequestParams {
-	return func(p requestParams, endpoint endpoint, logger *slog.Logger) {
+	return func(p requestParams, endpoint endpoint, v *paramValidator) {
 		p["image"] = "1"
 	}
 }
 
 // WithNoImage requests only articles without image.
 func WithNoImage() NewsRequestParams {
-	return func(p requestParams, endpoint endpoint, logger *slog.Logger) {
+	return func(p requestParams, endpoint endpoint, v *paramValidator) {
 		p["image"] = "0"
 	}
 }
 
 // WithOnlyVideo requests only articles with a video.
 func WithOnlyVideo() NewsRequestParams {
-	return func(p requestParams, endpoint endpoint, logger *slog.Logger) {
+	return func(p requestParams, endpoint endpoint, v *paramValidator) {
 		p["video"] = "1"
 	}
 }
 
 // WithNoVideo requests only articles without video.
 func WithNoVideo() NewsRequestParams {
-	return func(p requestParams, endpoint endpoint, logger *slog.Logger) {
+	return func(p requestParams, endpoint endpoint, v *paramValidator) {
 		p["video"] = "0"
 	}
 }
 
 // WithFromDate sets the start date for the article search.
 //
-// The date is formatted as YYYY-MM-DD in the request.
-func WithFromDate(date time.Time) NewsRequestParams {
-	return func(p requestParams, endpoint endpoint, logger *slog.Logger) {
+// The date is formatted as YYYY-MM-DD in the request. It is only supported by the news archive and crypto news endpoints.
+func WithFromDate(date time.Time) ArchiveCryptoParams {
+	return func(p requestParams, endpoint endpoint, v *paramValidator) {
 		p["from_date"] = date.Format("2006-01-02")
 	}
 }
 
 // WithToDate sets the end date for the article search.
 //
-// The date is formatted as YYYY-MM-DD in the request.
-func WithToDate(date time.Time) NewsRequestParams {
-	return func(p requestParams, endpoint endpoint, logger *slog.Logger) {
+// The date is formatted as YYYY-MM-DD in the request. It is only supported by the news archive and crypto news endpoints.
+func WithToDate(date time.Time) ArchiveCryptoParams {
+	return func(p requestParams, endpoint endpoint, v *paramValidator) {
 		p["to_date"] = date.Format("2006-01-02")
 	}
 }
 
 // WithTimeframe sets a time window for the article search.
 //
-// The timeframe can be specified in hours and minutes, up to 48 hours.
-func WithTimeframe(hours int, minutes int) NewsRequestParams {
-	return func(p requestParams, endpoint endpoint, logger *slog.Logger) {
+// The timeframe can be specified in hours and minutes, up to 48 hours. It is only supported by the latest news, crypto news and market news endpoints.
+func WithTimeframe(hours int, minutes int) LatestCryptoParams {
+	return func(p requestParams, endpoint endpoint, v *paramValidator) {
 		if hours+minutes == 0 || hours < 0 || minutes < 0 {
-			logger.Error("newsdata: timeframe arguments must be greater than 0")
+			v.error("timeframe", fmt.Sprintf("%dh%dm", hours, minutes), "newsdata: timeframe arguments must be greater than 0")
 			return
 		}
-		switch endpoint {
-		case endpointLatestNews:
-			if minutes == 0 {
-				if hours > 48 {
-					logger.Error("newsdata: timeframe must be between 0h and 48h")
-					return
-				}
-				p["timeframe"] = fmt.Sprintf("%d", hours)
-			} else {
-				totalMinutes := hours*60 + minutes
-				if totalMinutes > 2880 {
-					logger.Error("newsdata: timeframe must be between 0h and 48h")
-					return
-				}
-				p["timeframe"] = fmt.Sprintf("%dm", totalMinutes)
+		if minutes == 0 {
+			if hours > 48 {
+				v.error("timeframe", fmt.Sprintf("%dh%dm", hours, minutes), "newsdata: timeframe must be between 0h and 48h")
+				return
+			}
+			p["timeframe"] = fmt.Sprintf("%d", hours)
+		} else {
+			totalMinutes := hours*60 + minutes
+			if totalMinutes > 2880 {
+				v.error("timeframe", fmt.Sprintf("%dh%dm", hours, minutes), "newsdata: timeframe must be between 0h and 48h")
+				return
 			}
+			p["timeframe"] = fmt.Sprintf("%dm", totalMinutes)
 		}
 	}
 }
 
 // WithSentiment adds sentiment analysis filter to the article request.
 //
-// It validates the sentiment value against allowed options.
-func WithSentiment(sentiment string) NewsRequestParams {
-	return func(p requestParams, endpoint endpoint, logger *slog.Logger) {
-		if endpoint == endpointNewsArchive {
-			logger.Warn(fmt.Sprintf("newsdata: sentiment is not supported for %s", endpoint.String()))
-			return
-		}
+// It validates the sentiment value against allowed options. It is only supported by the latest news, crypto news and market news endpoints.
+func WithSentiment(sentiment string) LatestCryptoParams {
+	return func(p requestParams, endpoint endpoint, v *paramValidator) {
 		if sentiment == "" {
 			return
 		}
 		if !slices.Contains(allowedSentiments, sentiment) {
-			logger.Warn(fmt.Sprintf("newsdata: sentiment \"%s\" is not allowed", sentiment))
+			v.warn("sentiment", sentiment, fmt.Sprintf("newsdata: sentiment \"%s\" is not allowed", sentiment))
 			return
 		}
 		p["sentiment"] = sentiment
@@ -430,13 +545,13 @@ func WithSentiment(sentiment string) NewsRequestParams {
 // validateTags validates and filters the provided tags.
 //
 // It ensures only allowed tags are included.
-func validateTags(tags []string, logger *slog.Logger) []string {
+func validateTags(tags []string, v *paramValidator) []string {
 	safeTags := make([]string, 0, len(tags))
 	for _, tag := range tags {
 		if slices.Contains(allowedTags, tag) {
 			safeTags = append(safeTags, tag)
 		} else {
-			logger.Warn(fmt.Sprintf("newsdata: tag \"%s\" is not allowed", tag))
+			v.warn("tag", tag, fmt.Sprintf("newsdata: tag \"%s\" is not allowed", tag))
 		}
 	}
 	return safeTags
@@ -444,45 +559,37 @@ func validateTags(tags []string, logger *slog.Logger) []string {
 
 // WithTags adds tag filters to the article request.
 //
-// It accepts multiple tags and validates them against allowed values.
-func WithTags(tags ...string) NewsRequestParams {
-	return func(p requestParams, endpoint endpoint, logger *slog.Logger) {
+// It accepts multiple tags and validates them against allowed values. It is only supported by the latest news, crypto news and market news endpoints.
+func WithTags(tags ...string) LatestCryptoParams {
+	return func(p requestParams, endpoint endpoint, v *paramValidator) {
 		if len(tags) == 0 {
 			return
 		}
-		if endpoint == endpointNewsArchive {
-			logger.Warn(fmt.Sprintf("newsdata: tags are not supported for %s", endpoint.String()))
-			return
-		}
-		safeTags := validateTags(tags, logger)
-		if safeTags != nil {
+		safeTags := validateTags(tags, v)
+		if len(safeTags) > 0 {
 			p["tag"] = strings.Join(safeTags, ",")
 		}
 	}
 }
 
 // WithRemoveDuplicates enables duplicate article filtering in the response.
-// This option is not supported for news archive requests.
-func WithRemoveDuplicates() NewsRequestParams {
-	return func(p requestParams, endpoint endpoint, logger *slog.Logger) {
-		if endpoint == endpointNewsArchive {
-			logger.Warn(fmt.Sprintf("newsdata: remove duplicates is not supported for %s", endpoint.String()))
-			return
-		}
+// It is only supported by the latest news, crypto news and market news endpoints.
+func WithRemoveDuplicates() LatestCryptoParams {
+	return func(p requestParams, endpoint endpoint, v *paramValidator) {
 		p["removeduplicate"] = "1"
 	}
 }
 
 // WithCoins adds cryptocurrency coin filters to the article request.
 //
-// It accepts up to 5 coin symbols (like btc, eth, usdt, bnb, etc.) for filtering.
-func WithCoins(coins ...string) NewsRequestParams {
-	return func(p requestParams, endpoint endpoint, logger *slog.Logger) {
+// It accepts up to 5 coin symbols (like btc, eth, usdt, bnb, etc.) for filtering. It is only supported by the crypto news endpoint.
+func WithCoins(coins ...string) CryptoParams {
+	return func(p requestParams, endpoint endpoint, v *paramValidator) {
 		if len(coins) == 0 {
 			return
 		}
 		if len(coins) > 5 {
-			logger.Warn("newsdata: coins length is greater than 5, truncating to 5")
+			v.warn("coin", strings.Join(coins, ","), "newsdata: coins length is greater than 5, truncating to 5")
 			coins = coins[:5]
 		}
 		p["coin"] = strings.Join(coins, ",")
@@ -493,22 +600,84 @@ func WithCoins(coins ...string) NewsRequestParams {
 //
 // The value must be between 1 and 50.
 func WithSize(size int) NewsRequestParams {
-	return func(p requestParams, endpoint endpoint, logger *slog.Logger) {
+	return func(p requestParams, endpoint endpoint, v *paramValidator) {
 		if size < 1 || size > 50 {
-			logger.Error("newsdata: size must be between 1 and 50")
+			v.error("size", fmt.Sprintf("%d", size), "newsdata: size must be between 1 and 50")
 			return
 		}
 		p["size"] = fmt.Sprintf("%d", size)
 	}
 }
 
+// symbolPattern matches a stock ticker symbol, e.g. AAPL or BRK.B.
+var symbolPattern = regexp.MustCompile(`^[A-Z0-9]{1,10}([.-][A-Z0-9]{1,5})?$`)
+
+// WithSymbols adds stock ticker symbol filters to the article request.
+//
+// It accepts up to 5 symbols (like AAPL, MSFT, BRK.B, etc.). Symbols are upper-cased and validated.
+// It is only supported by the market news endpoint.
+func WithSymbols(symbols ...string) MarketParams {
+	return func(p requestParams, endpoint endpoint, v *paramValidator) {
+		if len(symbols) == 0 {
+			return
+		}
+		safeSymbols := make([]string, 0, len(symbols))
+		for _, symbol := range symbols {
+			symbol = strings.ToUpper(strings.TrimSpace(symbol))
+			if symbolPattern.MatchString(symbol) {
+				safeSymbols = append(safeSymbols, symbol)
+			} else {
+				v.warn("symbol", symbol, fmt.Sprintf("newsdata: symbol \"%s\" is not valid", symbol))
+			}
+		}
+		if len(safeSymbols) > 5 {
+			v.warn("symbol", strings.Join(symbols, ","), "newsdata: symbols length is greater than 5, truncating to 5")
+			safeSymbols = safeSymbols[:5]
+		}
+		if len(safeSymbols) > 0 {
+			p["symbol"] = strings.Join(safeSymbols, ",")
+		}
+	}
+}
+
+// WithOrganizations adds organization filters to the article request (e.g. "Apple", "Federal Reserve").
+//
+// It accepts up to 5 organizations. It is only supported by the market news endpoint.
+func WithOrganizations(organizations ...string) MarketParams {
+	return func(p requestParams, endpoint endpoint, v *paramValidator) {
+		if len(organizations) == 0 {
+			return
+		}
+		safeOrganizations := make([]string, 0, len(organizations))
+		for _, organization := range organizations {
+			organization = strings.TrimSpace(organization)
+			if organization == "" || strings.Contains(organization, ",") {
+				v.warn("organization", organization, fmt.Sprintf("newsdata: organization \"%s\" is not valid", organization))
+				continue
+			}
+			safeOrganizations = append(safeOrganizations, organization)
+		}
+		if len(safeOrganizations) > 5 {
+			v.warn("organization", strings.Join(organizations, ","), "newsdata: organizations length is greater than 5, truncating to 5")
+			safeOrganizations = safeOrganizations[:5]
+		}
+		if len(safeOrganizations) > 0 {
+			p["organization"] = strings.Join(safeOrganizations, ",")
+		}
+	}
+}
+
 // SourceRequestParams is a function type for configuring source request parameters.
-type SourceRequestParams func(p requestParams, endpoint endpoint, logger *slog.Logger)
+type SourceRequestParams func(p requestParams, endpoint endpoint, v *paramValidator)
+
+func (f SourceRequestParams) apply(p requestParams, endpoint endpoint, v *paramValidator) {
+	f(p, endpoint, v)
+}
 
 // WithCountry adds a country filter to the source request.
 // It validates the country code against allowed values.
 func WithCountry(country string) SourceRequestParams {
-	return func(p requestParams, endpoint endpoint, logger *slog.Logger) {
+	return func(p requestParams, endpoint endpoint, v *paramValidator) {
 		if country == "" {
 			return
 		}
@@ -518,13 +687,13 @@ func WithCountry(country string) SourceRequestParams {
 				return
 			}
 		}
-		logger.Warn(fmt.Sprintf("newsdata: country \"%s\" is not allowed", country))
+		v.warn("country", country, fmt.Sprintf("newsdata: country \"%s\" is not allowed", country))
 	}
 }
 
 // WithCategory adds category filter to the source request
 func WithCategory(category string) SourceRequestParams {
-	return func(p requestParams, endpoint endpoint, logger *slog.Logger) {
+	return func(p requestParams, endpoint endpoint, v *paramValidator) {
 		if category == "" {
 			return
 		}
@@ -534,13 +703,13 @@ func WithCategory(category string) SourceRequestParams {
 				return
 			}
 		}
-		logger.Warn(fmt.Sprintf("newsdata: category \"%s\" is not allowed", category))
+		v.warn("category", category, fmt.Sprintf("newsdata: category \"%s\" is not allowed", category))
 	}
 }
 
 // WithLanguage adds language filter to the source request
 func WithLanguage(language string) SourceRequestParams {
-	return func(p requestParams, endpoint endpoint, logger *slog.Logger) {
+	return func(p requestParams, endpoint endpoint, v *paramValidator) {
 		if language == "" {
 			return
 		}
@@ -550,17 +719,17 @@ func WithLanguage(language string) SourceRequestParams {
 				return
 			}
 		}
-		logger.Warn(fmt.Sprintf("newsdata: language \"%s\" is not allowed", language))
+		v.warn("language", language, fmt.Sprintf("newsdata: language \"%s\" is not allowed", language))
 	}
 }
 
 // WithPriorityDomain sets a priority domain for the source request
 func WithPriorityDomain(priorityDomain string) SourceRequestParams {
-	return func(p requestParams, endpoint endpoint, logger *slog.Logger) {
+	return func(p requestParams, endpoint endpoint, v *paramValidator) {
 		if priorityDomain == "" {
 			return
 		}
-		if !validatePriorityDomain(priorityDomain, logger) {
+		if !validatePriorityDomain(priorityDomain, v) {
 			return
 		}
 		p["prioritydomain"] = priorityDomain
@@ -569,7 +738,7 @@ func WithPriorityDomain(priorityDomain string) SourceRequestParams {
 
 // WithDomainUrl sets a domain URL filter for the source request
 func WithDomainUrl(domainUrl string) SourceRequestParams {
-	return func(p requestParams, endpoint endpoint, logger *slog.Logger) {
+	return func(p requestParams, endpoint endpoint, v *paramValidator) {
 		if domainUrl == "" {
 			return
 		}
@@ -0,0 +1,106 @@
+package newsdata
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Quota is a snapshot of the API credits, as reported by the rate limit headers of the latest response.
+//
+// Limit and Remaining are -1, and Reset is zero, until the API sends the corresponding header. A response
+// without the header, e.g. an error response, keeps the previous value.
+type Quota struct {
+	Limit     int       // Number of credits allowed in the current window (-1 if unknown)
+	Remaining int       // Number of credits remaining in the current window (-1 if unknown)
+	Reset     time.Time // Time at which the credits are reset (zero if unknown)
+	UpdatedAt time.Time // Time at which the snapshot was taken (zero if no response was received yet)
+}
+
+// IsKnown reports whether the snapshot contains the remaining credits.
+func (q Quota) IsKnown() bool {
+	return q.Remaining >= 0 && !q.UpdatedAt.IsZero()
+}
+
+// Headers used by the API to report credits. The first header found is used.
+var (
+	quotaLimitHeaders     = []string{"X-RateLimit-Limit", "X-Ratelimit-Limit-Credits", "X-API-Credits-Limit"}
+	quotaRemainingHeaders = []string{"X-RateLimit-Remaining", "X-Ratelimit-Remaining-Credits", "X-API-Credits-Remaining"}
+	quotaResetHeaders     = []string{"X-RateLimit-Reset", "X-Ratelimit-Reset-Credits", "X-API-Credits-Reset"}
+)
+
+// parseQuota extracts the credits information from the response headers.
+func parseQuota(header http.Header, now time.Time) Quota {
+	quota := Quota{
+		Limit:     headerInt(header, quotaLimitHeaders),
+		Remaining: headerInt(header, quotaRemainingHeaders),
+		UpdatedAt: now,
+	}
+	if reset := headerInt(header, quotaResetHeaders); reset >= 0 {
+		// The reset header is either a Unix timestamp or a number of seconds until the reset.
+		if reset > 1_000_000_000 {
+			quota.Reset = time.Unix(int64(reset), 0)
+		} else {
+			quota.Reset = now.Add(time.Duration(reset) * time.Second)
+		}
+	}
+	return quota
+}
+
+// headerInt returns the value of the first header found as an int, or -1 if none is found or valid.
+func headerInt(header http.Header, keys []string) int {
+	for _, key := range keys {
+		value := header.Get(key)
+		if value == "" {
+			continue
+		}
+		if n, err := strconv.Atoi(value); err == nil {
+			return n
+		}
+	}
+	return -1
+}
+
+// WithCreditBudget limits the number of credits the client may spend. Every request sent spends a credit,
+// including the retries and the failed requests, since the API may bill them.
+//
+// Once the budget is spent, every request fails with ErrCreditBudgetExceeded, which stops Stream
+// and Get cleanly. A budget of 0 (the default) means no limit.
+func WithCreditBudget(credits int) NewsDataClientOption {
+	return func(o *clientOptions) {
+		o.creditBudget = credits
+	}
+}
+
+// Quota returns the latest credits snapshot reported by the API.
+func (c *NewsDataClient) Quota() Quota {
+	c.quotaMu.Lock()
+	defer c.quotaMu.Unlock()
+	return c.quota
+}
+
+// CreditsUsed returns the number of credits (i.e. requests sent, including retries) spent by the client so far.
+func (c *NewsDataClient) CreditsUsed() int {
+	return int(c.creditsUsed.Load())
+}
+
+// setQuota stores the latest credits snapshot, and returns the snapshot stored. Fields missing from the snapshot
+// keep their previous value, and the snapshot is ignored if all of them are missing.
+func (c *NewsDataClient) setQuota(quota Quota) Quota {
+	c.quotaMu.Lock()
+	defer c.quotaMu.Unlock()
+	if quota.Limit < 0 && quota.Remaining < 0 && quota.Reset.IsZero() {
+		return c.quota
+	}
+	if quota.Limit < 0 {
+		quota.Limit = c.quota.Limit
+	}
+	if quota.Remaining < 0 {
+		quota.Remaining = c.quota.Remaining
+	}
+	if quota.Reset.IsZero() {
+		quota.Reset = c.quota.Reset
+	}
+	c.quota = quota
+	return quota
+}
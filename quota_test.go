@@ -0,0 +1,105 @@
+package newsdata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestQuotaAndCreditBudget(t *testing.T) {
+	page := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page++
+		w.Header().Set("X-RateLimit-Limit", "200")
+		w.Header().Set("X-RateLimit-Remaining", fmt.Sprintf("%d", 200-page))
+		w.Header().Set("X-RateLimit-Reset", "60")
+		fmt.Fprintf(w, `{"status":"success","totalResults":100,"results":[{"article_id":"%d"}],"nextPage":"page%d"}`, page, page+1)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test"), WithBaseURL(server.URL), WithCreditBudget(2))
+	articles, errChan := client.NewsArchive.Stream(context.Background(), "")
+	count := 0
+	for range articles {
+		count++
+	}
+	if err := <-errChan; !errors.Is(err, ErrCreditBudgetExceeded) {
+		t.Fatalf("Error should be ErrCreditBudgetExceeded: %v", err)
+	}
+	if count != 2 || client.CreditsUsed() != 2 {
+		t.Fatalf("Invalid result: %d articles, %d credits - should be 2 articles, 2 credits", count, client.CreditsUsed())
+	}
+	quota := client.Quota()
+	if !quota.IsKnown() || quota.Limit != 200 || quota.Remaining != 198 || quota.Reset.IsZero() {
+		t.Fatalf("Invalid quota: %+v", quota)
+	}
+}
+
+func TestQuotaMissingHeaders(t *testing.T) {
+	page := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page++
+		switch page {
+		case 1:
+			w.Header().Set("X-RateLimit-Limit", "200")
+			w.Header().Set("X-RateLimit-Remaining", "150")
+			w.Header().Set("X-RateLimit-Reset", "60")
+		case 2:
+			w.Header().Set("X-RateLimit-Remaining", "149")
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, `{"status":"error","results":{"code":"ServerError","message":"Internal error"}}`)
+			return
+		}
+		fmt.Fprintf(w, `{"status":"success","totalResults":100,"results":[{"article_id":"%d"}],"nextPage":"page%d"}`, page, page+1)
+	}))
+	defer server.Close()
+
+	// The second response only reports the remaining credits, and the error response none.
+	client := NewClient(WithAPIKey("test"), WithBaseURL(server.URL), WithRetryPolicy(RetryPolicy{MaxAttempts: 1}))
+	var err error
+	for page, pageErr := range client.NewsArchive.Pages(context.Background(), "") {
+		if err = pageErr; err != nil {
+			break
+		}
+		if page.Quota != client.Quota() {
+			t.Fatalf("Invalid page quota: %+v - should be the client quota %+v", page.Quota, client.Quota())
+		}
+	}
+	if err == nil {
+		t.Fatalf("Error response should fail")
+	}
+	quota := client.Quota()
+	if !quota.IsKnown() || quota.Limit != 200 || quota.Remaining != 149 || quota.Reset.IsZero() {
+		t.Fatalf("Invalid quota: %+v - should keep the values of the previous responses", quota)
+	}
+}
+
+func TestCreditBudgetRetries(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprint(w, `{"status":"error","results":{"code":"ServerError","message":"Service unavailable"}}`)
+	}))
+	defer server.Close()
+
+	// Every attempt is billed: the retries stop once the budget is spent.
+	client := NewClient(WithAPIKey("test"), WithBaseURL(server.URL), WithLogWriter(io.Discard), WithCreditBudget(3),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 5, BaseDelay: time.Millisecond}))
+	_, err := client.LatestNews.Get(context.Background(), "", 0)
+	if !errors.Is(err, ErrCreditBudgetExceeded) {
+		t.Fatalf("Error should be ErrCreditBudgetExceeded: %v", err)
+	}
+	if calls != 3 || client.CreditsUsed() != 3 {
+		t.Fatalf("Invalid result: %d calls, %d credits - should be 3 calls, 3 credits", calls, client.CreditsUsed())
+	}
+	if _, err := client.LatestNews.Get(context.Background(), "", 0); !errors.Is(err, ErrCreditBudgetExceeded) || calls != 3 {
+		t.Fatalf("Spent budget should fail without sending a request: %v", err)
+	}
+}
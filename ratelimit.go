@@ -0,0 +1,125 @@
+package newsdata
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// WithRateLimit limits the client to the given number of requests per period.
+//
+// Every request made by the client (including retries) waits for the limiter, so all services
+// sharing the client share the same budget. E.g. WithRateLimit(1800, 15*time.Minute) matches
+// a plan allowing 1800 credits every 15 minutes.
+func WithRateLimit(requests int, per time.Duration) NewsDataClientOption {
+	return func(o *clientOptions) {
+		o.rateLimitRequests = requests
+		o.rateLimitPeriod = per
+	}
+}
+
+// RateLimiterStats is a snapshot of the client-side rate limiter state.
+type RateLimiterStats struct {
+	Enabled bool          // Whether a rate limit is configured
+	Tokens  float64       // Number of requests currently available without waiting
+	Wait    time.Duration // Time to wait before the next request can be made (0 if a token is available)
+}
+
+// rateLimiter is a token bucket rate limiter.
+//
+// The bucket holds at most burst tokens and is refilled continuously at rate tokens per second.
+type rateLimiter struct {
+	mu       sync.Mutex
+	rate     float64 // tokens per second
+	burst    float64
+	tokens   float64
+	lastFill time.Time
+}
+
+// newRateLimiter creates a token bucket allowing requests per period, starting full.
+// It returns nil if requests or per are not strictly positive, which disables rate limiting.
+func newRateLimiter(requests int, per time.Duration) *rateLimiter {
+	if requests <= 0 || per <= 0 {
+		return nil
+	}
+	return &rateLimiter{
+		rate:     float64(requests) / per.Seconds(),
+		burst:    float64(requests),
+		tokens:   float64(requests),
+		lastFill: time.Now(),
+	}
+}
+
+// refill adds the tokens accumulated since the last refill. It must be called with mu held.
+func (l *rateLimiter) refill(now time.Time) {
+	l.tokens += now.Sub(l.lastFill).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.lastFill = now
+}
+
+// reserve takes a token and returns how long the caller must wait before using it.
+// The token may be borrowed (tokens < 0) so concurrent callers are served in order.
+func (l *rateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.refill(time.Now())
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// cancel gives back a token reserved by reserve, when the caller gave up waiting.
+func (l *rateLimiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.refill(time.Now())
+	l.tokens++
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+}
+
+// wait blocks until a token is available or the context is done.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	delay := l.reserve()
+	if delay == 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.cancel()
+		return fmt.Errorf("rateLimiter - context done while waiting: %w", ctx.Err())
+	}
+}
+
+// stats returns a snapshot of the limiter state.
+func (l *rateLimiter) stats() RateLimiterStats {
+	if l == nil {
+		return RateLimiterStats{}
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.refill(time.Now())
+	stats := RateLimiterStats{Enabled: true, Tokens: l.tokens}
+	if l.tokens < 1 {
+		stats.Wait = time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+	}
+	return stats
+}
+
+// RateLimiterStats returns a snapshot of the client-side rate limiter state.
+func (c *NewsDataClient) RateLimiterStats() RateLimiterStats {
+	return c.rateLimiter.stats()
+}
@@ -0,0 +1,44 @@
+package newsdata
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter(t *testing.T) {
+	limiter := newRateLimiter(2, 100*time.Millisecond)
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := limiter.wait(context.Background()); err != nil {
+			t.Fatalf("Error waiting for rate limiter: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Fatalf("Third request should have waited: %v", elapsed)
+	}
+	if stats := limiter.stats(); !stats.Enabled || stats.Tokens >= 1 || stats.Wait == 0 {
+		t.Fatalf("Invalid rate limiter stats: %+v", stats)
+	}
+}
+
+func TestRateLimiterCancel(t *testing.T) {
+	limiter := newRateLimiter(1, time.Hour)
+	limiter.wait(context.Background())
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := limiter.wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Error should be context.DeadlineExceeded: %v", err)
+	}
+	if stats := limiter.stats(); stats.Tokens < -0.01 {
+		t.Fatalf("Cancelled reservation should be given back: %+v", stats)
+	}
+}
+
+func TestRateLimiterDisabled(t *testing.T) {
+	client := NewClient(WithAPIKey("test"))
+	if stats := client.RateLimiterStats(); stats.Enabled {
+		t.Fatalf("Rate limiter should be disabled: %+v", stats)
+	}
+}
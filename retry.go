@@ -0,0 +1,112 @@
+package newsdata
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy configures how the client retries requests that failed with a transient error.
+//
+// The delay between two attempts grows exponentially from BaseDelay up to MaxDelay, plus a random jitter.
+// If the API answered with a Retry-After header, the client waits at least that long.
+type RetryPolicy struct {
+	MaxAttempts int              // Maximum number of attempts, including the first one. 1 or less disables retries.
+	BaseDelay   time.Duration    // Delay before the first retry
+	MaxDelay    time.Duration    // Maximum delay between two attempts (before jitter)
+	Jitter      float64          // Random jitter added to the delay, as a fraction of the delay (e.g. 0.2 for up to 20%)
+	RetryIf     func(error) bool // Predicate deciding if an error is retryable. If nil, IsRetryable is used.
+}
+
+// DefaultRetryPolicy is a sensible retry policy: 4 attempts, starting at 500ms and up to 10s between attempts.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    10 * time.Second,
+	Jitter:      0.2,
+}
+
+// WithRetryPolicy sets the retry policy used for every request made by the client.
+//
+// By default, the client does not retry failed requests.
+func WithRetryPolicy(policy RetryPolicy) NewsDataClientOption {
+	return func(o *clientOptions) {
+		o.retryPolicy = policy
+	}
+}
+
+// IsRetryable reports whether err is a transient error worth retrying: rate limiting, server errors (5xx),
+// timeouts, connections reset or refused, and connections closed before the response was complete.
+// Other network errors (e.g. TLS certificate or DNS errors) and context cancellation are never retryable.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		if errors.Is(apiErr, ErrQuotaExceeded) {
+			return false
+		}
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= 500
+	}
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+	// Every *url.Error is a net.Error: only its timeouts are transient.
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// shouldRetry reports whether a request that failed with err at the given attempt should be retried.
+func (p RetryPolicy) shouldRetry(attempt int, err error) bool {
+	if attempt >= p.MaxAttempts {
+		return false
+	}
+	if p.RetryIf != nil {
+		return p.RetryIf(err)
+	}
+	return IsRetryable(err)
+}
+
+// delay returns the time to wait before the next attempt.
+func (p RetryPolicy) delay(attempt int, err error) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempt && (p.MaxDelay <= 0 || delay < p.MaxDelay); i++ {
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if p.Jitter > 0 && delay > 0 {
+		delay += time.Duration(rand.Float64() * p.Jitter * float64(delay))
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > delay {
+		delay = apiErr.RetryAfter
+	}
+	return delay
+}
+
+// parseRetryAfter parses the value of a Retry-After header, either in seconds or as an HTTP date.
+// It returns 0 if the value is empty or invalid.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if d := time.Until(date); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
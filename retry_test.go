@@ -0,0 +1,128 @@
+package newsdata
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"status":"error","results":{"message":"Service unavailable","code":"ServerError"}}`))
+			return
+		}
+		w.Write([]byte(`{"status":"success","totalResults":1,"results":[{"article_id":"1","title":"Title"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test"), WithBaseURL(server.URL), WithRetryPolicy(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}))
+	articles, err := client.LatestNews.Get(context.Background(), "", 1)
+	if err != nil {
+		t.Fatalf("Error fetching Latest News: %v", err)
+	}
+	if len(articles) != 1 || calls.Load() != 3 {
+		t.Fatalf("Invalid result: %d articles, %d calls - should be 1 article, 3 calls", len(articles), calls.Load())
+	}
+}
+
+func TestRetryPolicyNotRetryable(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"status":"error","results":{"message":"API key invalid","code":"Unauthorized"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test"), WithBaseURL(server.URL), WithRetryPolicy(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}))
+	_, err := client.LatestNews.Get(context.Background(), "", 0)
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("Error should be ErrUnauthorized: %v", err)
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("Invalid number of calls: %d - should be 1", calls.Load())
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	if d := parseRetryAfter("3"); d != 3*time.Second {
+		t.Fatalf("Invalid Retry-After: %v - should be 3s", d)
+	}
+	policy := RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond}
+	if d := policy.delay(1, &APIError{StatusCode: http.StatusTooManyRequests, RetryAfter: time.Second}); d != time.Second {
+		t.Fatalf("Invalid delay: %v - should honor Retry-After", d)
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	urlErr := func(err error) error {
+		return &url.Error{Op: "Get", URL: "https://newsdata.io/api/1/latest", Err: err}
+	}
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"server error", &APIError{StatusCode: http.StatusInternalServerError}, true},
+		{"rate limited", &APIError{StatusCode: http.StatusTooManyRequests}, true},
+		{"unauthorized", &APIError{StatusCode: http.StatusUnauthorized}, false},
+		{"connection reset", urlErr(&net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}), true},
+		{"connection refused", urlErr(&net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}), true},
+		{"unexpected EOF", urlErr(io.ErrUnexpectedEOF), true},
+		{"timeout", urlErr(&net.DNSError{Err: "i/o timeout", IsTimeout: true}), true},
+		{"certificate", urlErr(x509.UnknownAuthorityError{}), false},
+		{"unknown host", urlErr(&net.DNSError{Err: "no such host", IsNotFound: true}), false},
+		{"unsupported scheme", urlErr(errors.New(`unsupported protocol scheme "ftp"`)), false},
+		{"canceled", urlErr(context.Canceled), false},
+	}
+	for _, test := range tests {
+		if got := IsRetryable(test.err); got != test.want {
+			t.Fatalf("Invalid IsRetryable for %s: %t - should be %t", test.name, got, test.want)
+		}
+	}
+}
+
+// countingTransport counts the requests sent through it.
+type countingTransport struct {
+	calls atomic.Int32
+	next  http.RoundTripper
+}
+
+func (c *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.calls.Add(1)
+	return c.next.RoundTrip(req)
+}
+
+func TestRetryPolicyCertificateError(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Config.ErrorLog = log.New(io.Discard, "", 0)
+	defer server.Close()
+
+	// The certificate of the test server is not trusted by the default transport.
+	transport := &countingTransport{next: http.DefaultTransport}
+	client := NewClient(WithAPIKey("test"), WithBaseURL(server.URL), WithTransport(transport), WithLogWriter(io.Discard),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}))
+	_, err := client.LatestNews.Get(context.Background(), "", 0)
+	var certErr *tls.CertificateVerificationError
+	if !errors.As(err, &certErr) {
+		t.Fatalf("Error should be a certificate error: %v", err)
+	}
+	if transport.calls.Load() != 1 {
+		t.Fatalf("Invalid number of calls: %d - should be 1", transport.calls.Load())
+	}
+}
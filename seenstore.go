@@ -0,0 +1,397 @@
+package newsdata
+
+import (
+	"bufio"
+	"bytes"
+	"container/list"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// SeenStore remembers the keys (e.g. article IDs) already processed, so that a Watcher or a Deduper
+// does not process them twice. Implementations must be safe for concurrent use.
+type SeenStore interface {
+	// Add marks the keys as seen now.
+	Add(keys ...string) error
+	// Has reports whether the key was seen, and has not expired.
+	Has(key string) (bool, error)
+	// Expire forgets the keys seen before the given time.
+	Expire(before time.Time) error
+}
+
+// MemorySeenStore is an in-memory SeenStore, bounded in size (least recently used keys are forgotten first)
+// and in time (keys expire after a TTL).
+type MemorySeenStore struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	entries  map[string]*list.Element
+	lru      *list.List // Keys from the most recently used to the least recently used
+	now      func() time.Time
+}
+
+// memoryEntry is a key of a MemorySeenStore.
+type memoryEntry struct {
+	key    string
+	seenAt time.Time
+}
+
+// NewMemorySeenStore creates an in-memory SeenStore remembering at most capacity keys, for at most ttl.
+//
+// A capacity or a ttl of 0 or less means no limit.
+func NewMemorySeenStore(capacity int, ttl time.Duration) *MemorySeenStore {
+	return &MemorySeenStore{
+		capacity: capacity,
+		ttl:      ttl,
+		entries:  make(map[string]*list.Element),
+		lru:      list.New(),
+		now:      time.Now,
+	}
+}
+
+// Add implements SeenStore.Add. Adding a key already seen refreshes it.
+func (s *MemorySeenStore) Add(keys ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	for _, key := range keys {
+		if e, ok := s.entries[key]; ok {
+			e.Value.(*memoryEntry).seenAt = now
+			s.lru.MoveToFront(e)
+			continue
+		}
+		s.entries[key] = s.lru.PushFront(&memoryEntry{key: key, seenAt: now})
+		if s.capacity > 0 && s.lru.Len() > s.capacity {
+			s.remove(s.lru.Back())
+		}
+	}
+	return nil
+}
+
+// Has implements SeenStore.Has. A key found is marked as recently used.
+func (s *MemorySeenStore) Has(key string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.entries[key]
+	if !ok {
+		return false, nil
+	}
+	if s.ttl > 0 && s.now().Sub(e.Value.(*memoryEntry).seenAt) >= s.ttl {
+		s.remove(e)
+		return false, nil
+	}
+	s.lru.MoveToFront(e)
+	return true, nil
+}
+
+// Expire implements SeenStore.Expire.
+func (s *MemorySeenStore) Expire(before time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, e := range s.entries {
+		if e.Value.(*memoryEntry).seenAt.Before(before) {
+			s.remove(s.entries[key])
+		}
+	}
+	return nil
+}
+
+// Len returns the number of keys in the store, including the expired keys not yet removed.
+func (s *MemorySeenStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lru.Len()
+}
+
+// remove removes an entry. It must be called with mu held.
+func (s *MemorySeenStore) remove(e *list.Element) {
+	s.lru.Remove(e)
+	delete(s.entries, e.Value.(*memoryEntry).key)
+}
+
+// minCompactRecords is the minimum number of records in the log of a FileSeenStore before it is compacted.
+const minCompactRecords = 1000
+
+// FileSeenStore is a SeenStore persisted in a file, so that the keys seen survive process restarts.
+//
+// The file is an append-only log of JSON records, one per line. It is replayed when the store is opened,
+// and compacted (rewritten with only the live keys) when it holds more than twice as many records as live keys.
+// Keys are also kept in memory.
+type FileSeenStore struct {
+	mu      sync.Mutex
+	path    string
+	ttl     time.Duration
+	file    *os.File
+	writer  *bufio.Writer
+	keys    map[string]time.Time
+	records int       // Number of records in the log
+	purged  time.Time // Last time the expired keys were removed
+	now     func() time.Time
+}
+
+// seenRecord is a record of the log of a FileSeenStore: a key seen, or the expiration of the keys seen before a time.
+type seenRecord struct {
+	Key    string `json:"key,omitempty"`
+	SeenAt int64  `json:"seen_at,omitempty"` // Unix time in nanoseconds at which the key was seen
+	Expire int64  `json:"expire,omitempty"`  // Unix time in nanoseconds before which the keys expire
+}
+
+// OpenFileSeenStore opens or creates a file-backed SeenStore. Keys expire after ttl, 0 or less meaning never.
+//
+// A truncated last record, e.g. after a crash, is removed from the log. Any other malformed record fails.
+// The store must be closed with Close.
+func OpenFileSeenStore(path string, ttl time.Duration) (*FileSeenStore, error) {
+	s := &FileSeenStore{
+		path: path,
+		ttl:  ttl,
+		keys: make(map[string]time.Time),
+		now:  time.Now,
+	}
+	log, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	if log.valid < log.size {
+		if err := os.Truncate(path, log.valid); err != nil {
+			return nil, fmt.Errorf("newsdata: removing truncated record of seen store - error: %w", err)
+		}
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("newsdata: opening seen store - error: %w", err)
+	}
+	if log.unterminated {
+		// Terminate the last record, so that the next records are not appended to it.
+		if _, err := file.WriteString("\n"); err != nil {
+			file.Close()
+			return nil, fmt.Errorf("newsdata: opening seen store - error: %w", err)
+		}
+	}
+	s.file = file
+	s.writer = bufio.NewWriter(file)
+	return s, nil
+}
+
+// seenLog describes the log of a FileSeenStore as loaded.
+type seenLog struct {
+	size         int64 // Size of the log
+	valid        int64 // Size of the log without its truncated last record
+	unterminated bool  // Whether the last record is valid but not followed by a newline
+}
+
+// load replays the log. A truncated last record, e.g. after a crash, is ignored, and any other malformed record fails.
+func (s *FileSeenStore) load() (seenLog, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return seenLog{}, nil
+	}
+	if err != nil {
+		return seenLog{}, fmt.Errorf("newsdata: reading seen store - error: %w", err)
+	}
+	log := seenLog{size: int64(len(data)), valid: int64(len(data))}
+	lines := bytes.Split(data, []byte("\n"))
+	for i, line := range lines {
+		if len(line) == 0 {
+			continue
+		}
+		last := i == len(lines)-1 // Not followed by a newline
+		var record seenRecord
+		if err := json.Unmarshal(line, &record); err != nil {
+			if !last {
+				return seenLog{}, fmt.Errorf("newsdata: reading seen store - malformed record - line: %d: %w", i+1, err)
+			}
+			log.valid -= int64(len(line))
+			break
+		}
+		log.unterminated = last
+		s.records++
+		s.apply(record)
+	}
+	return log, nil
+}
+
+// apply applies a record to the keys in memory.
+func (s *FileSeenStore) apply(record seenRecord) {
+	if record.Expire != 0 {
+		expire := time.Unix(0, record.Expire)
+		for key, seenAt := range s.keys {
+			if seenAt.Before(expire) {
+				delete(s.keys, key)
+			}
+		}
+		return
+	}
+	s.keys[record.Key] = time.Unix(0, record.SeenAt)
+}
+
+// append writes records to the log and flushes it. It must be called with mu held.
+func (s *FileSeenStore) append(records ...seenRecord) error {
+	if s.file == nil {
+		return errors.New("newsdata: seen store is closed")
+	}
+	for _, record := range records {
+		data, err := json.Marshal(record)
+		if err != nil {
+			return fmt.Errorf("newsdata: writing seen store - error: %w", err)
+		}
+		if _, err := s.writer.Write(append(data, '\n')); err != nil {
+			return fmt.Errorf("newsdata: writing seen store - error: %w", err)
+		}
+		s.records++
+	}
+	if err := s.writer.Flush(); err != nil {
+		return fmt.Errorf("newsdata: writing seen store - error: %w", err)
+	}
+	return nil
+}
+
+// Add implements SeenStore.Add.
+func (s *FileSeenStore) Add(keys ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	records := make([]seenRecord, len(keys))
+	for i, key := range keys {
+		records[i] = seenRecord{Key: key, SeenAt: now.UnixNano()}
+	}
+	if err := s.append(records...); err != nil {
+		return err
+	}
+	for _, key := range keys {
+		s.keys[key] = now
+	}
+	return s.compactIfNeeded()
+}
+
+// Has implements SeenStore.Has.
+func (s *FileSeenStore) Has(key string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	seenAt, ok := s.keys[key]
+	if ok && s.ttl > 0 && s.now().Sub(seenAt) >= s.ttl {
+		return false, nil
+	}
+	return ok, nil
+}
+
+// Expire implements SeenStore.Expire.
+func (s *FileSeenStore) Expire(before time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	record := seenRecord{Expire: before.UnixNano()}
+	if err := s.append(record); err != nil {
+		return err
+	}
+	s.apply(record)
+	return s.compactIfNeeded()
+}
+
+// Len returns the number of keys in the store, including the expired keys not yet removed.
+func (s *FileSeenStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.keys)
+}
+
+// Compact rewrites the log with only the keys not expired.
+func (s *FileSeenStore) Compact() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.compact()
+}
+
+// compactIfNeeded compacts the log if it holds more than twice as many records as keys not expired.
+// It must be called with mu held.
+func (s *FileSeenStore) compactIfNeeded() error {
+	if s.records < minCompactRecords {
+		return nil
+	}
+	// Expired keys are removed at most every tenth of the TTL, not to scan all the keys on each Add.
+	if now := s.now(); s.ttl > 0 && now.Sub(s.purged) >= s.ttl/10 {
+		s.purgeExpired(now)
+	}
+	if s.records <= 2*len(s.keys) {
+		return nil
+	}
+	return s.compact()
+}
+
+// purgeExpired removes the expired keys from memory. It must be called with mu held.
+func (s *FileSeenStore) purgeExpired(now time.Time) {
+	expire := now.Add(-s.ttl)
+	for key, seenAt := range s.keys {
+		if !seenAt.After(expire) {
+			delete(s.keys, key)
+		}
+	}
+	s.purged = now
+}
+
+// compact rewrites the log in a temporary file, then replaces the log with it. It must be called with mu held.
+func (s *FileSeenStore) compact() error {
+	if s.file == nil {
+		return errors.New("newsdata: seen store is closed")
+	}
+	if s.ttl > 0 {
+		s.purgeExpired(s.now())
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("newsdata: compacting seen store - error: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // No-op once renamed
+	writer := bufio.NewWriter(tmp)
+	encoder := json.NewEncoder(writer)
+	for key, seenAt := range s.keys {
+		if err := encoder.Encode(seenRecord{Key: key, SeenAt: seenAt.UnixNano()}); err != nil {
+			tmp.Close()
+			return fmt.Errorf("newsdata: compacting seen store - error: %w", err)
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("newsdata: compacting seen store - error: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("newsdata: compacting seen store - error: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("newsdata: compacting seen store - error: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("newsdata: compacting seen store - error: %w", err)
+	}
+	s.file.Close()
+	s.file = nil
+	file, err := os.OpenFile(s.path, os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("newsdata: compacting seen store - error: %w", err)
+	}
+	s.file = file
+	s.writer = bufio.NewWriter(file)
+	s.records = len(s.keys)
+	return nil
+}
+
+// Close flushes and closes the log.
+func (s *FileSeenStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return nil
+	}
+	err := s.writer.Flush()
+	if closeErr := s.file.Close(); err == nil {
+		err = closeErr
+	}
+	s.file = nil
+	return err
+}
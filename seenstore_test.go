@@ -0,0 +1,226 @@
+package newsdata
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeClock is a clock advanced manually.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func assertSeen(t *testing.T, store SeenStore, key string, want bool) {
+	t.Helper()
+	seen, err := store.Has(key)
+	if err != nil {
+		t.Fatalf("Error reading %s: %v", key, err)
+	}
+	if seen != want {
+		t.Fatalf("Key %s seen should be %t", key, want)
+	}
+}
+
+func TestMemorySeenStore(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	store := NewMemorySeenStore(3, time.Hour)
+	store.now = clock.Now
+
+	store.Add("a", "b", "c")
+	assertSeen(t, store, "a", true) // "a" becomes the most recently used
+	store.Add("d")                  // "b" is the least recently used
+	assertSeen(t, store, "b", false)
+	assertSeen(t, store, "a", true)
+	if store.Len() != 3 {
+		t.Fatalf("Store should hold 3 keys, got %d", store.Len())
+	}
+
+	clock.now = clock.now.Add(30 * time.Minute)
+	store.Add("c")
+	clock.now = clock.now.Add(45 * time.Minute)
+	assertSeen(t, store, "a", false) // Expired
+	assertSeen(t, store, "c", true)  // Refreshed
+
+	store.Expire(clock.now)
+	assertSeen(t, store, "c", false)
+	if store.Len() != 0 {
+		t.Fatalf("Store should be empty, got %d keys", store.Len())
+	}
+}
+
+func TestFileSeenStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seen.log")
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	store, err := OpenFileSeenStore(path, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("Error opening store: %v", err)
+	}
+	store.now = clock.Now
+	if err := store.Add("a", "b"); err != nil {
+		t.Fatalf("Error adding keys: %v", err)
+	}
+	clock.now = clock.now.Add(time.Hour)
+	store.Add("c")
+	if err := store.Expire(clock.now.Add(-time.Minute)); err != nil {
+		t.Fatalf("Error expiring keys: %v", err)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatalf("Error closing store: %v", err)
+	}
+
+	// Simulate a crash while writing a record.
+	file, _ := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0644)
+	file.WriteString(`{"key":"d","seen_`)
+	file.Close()
+
+	store, err = OpenFileSeenStore(path, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("Error reopening store: %v", err)
+	}
+	store.now = clock.Now
+	assertSeen(t, store, "a", false)
+	assertSeen(t, store, "b", false)
+	assertSeen(t, store, "c", true)
+	assertSeen(t, store, "d", false)
+	if err := store.Add("e"); err != nil {
+		t.Fatalf("Error adding key: %v", err)
+	}
+	store.Close()
+
+	store, err = OpenFileSeenStore(path, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("Error reopening store: %v", err)
+	}
+	defer store.Close()
+	store.now = clock.Now
+	assertSeen(t, store, "e", true) // Written after the truncated record
+	if data, _ := os.ReadFile(path); bytes.Contains(data, []byte(`"d"`)) {
+		t.Fatalf("Truncated record should be removed from the log:\n%s", data)
+	}
+	clock.now = clock.now.Add(24 * time.Hour)
+	assertSeen(t, store, "c", false) // Expired by the TTL
+}
+
+func TestFileSeenStoreMalformed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seen.log")
+	os.WriteFile(path, []byte(`{"key":"a","seen_at":1}`+"\nnot json\n"+`{"key":"b","seen_at":1}`+"\n"), 0644)
+	if _, err := OpenFileSeenStore(path, 0); err == nil || !strings.Contains(err.Error(), "line: 2") {
+		t.Fatalf("Malformed record should fail with its line number: %v", err)
+	}
+
+	// A complete last record without newline is kept.
+	os.WriteFile(path, []byte(`{"key":"a","seen_at":1}`), 0644)
+	store, err := OpenFileSeenStore(path, 0)
+	if err != nil {
+		t.Fatalf("Error opening store: %v", err)
+	}
+	store.Add("b")
+	store.Close()
+	store, err = OpenFileSeenStore(path, 0)
+	if err != nil {
+		t.Fatalf("Error reopening store: %v", err)
+	}
+	defer store.Close()
+	assertSeen(t, store, "a", true)
+	assertSeen(t, store, "b", true)
+}
+
+func TestFileSeenStoreCompaction(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seen.log")
+	store, err := OpenFileSeenStore(path, 0)
+	if err != nil {
+		t.Fatalf("Error opening store: %v", err)
+	}
+	for range 2 * minCompactRecords {
+		if err := store.Add("a", "b"); err != nil {
+			t.Fatalf("Error adding keys: %v", err)
+		}
+	}
+	if err := store.Close(); err != nil {
+		t.Fatalf("Error closing store: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Error reading log: %v", err)
+	}
+	if lines := bytes.Count(data, []byte("\n")); lines > 2*minCompactRecords {
+		t.Fatalf("Log should be compacted, got %d records", lines)
+	}
+
+	store, err = OpenFileSeenStore(path, 0)
+	if err != nil {
+		t.Fatalf("Error reopening store: %v", err)
+	}
+	defer store.Close()
+	if store.Len() != 2 {
+		t.Fatalf("Store should hold 2 keys after compaction, got %d", store.Len())
+	}
+}
+
+func TestFileSeenStoreTTLCompaction(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seen.log")
+	store, err := OpenFileSeenStore(path, time.Minute)
+	if err != nil {
+		t.Fatalf("Error opening store: %v", err)
+	}
+	defer store.Close()
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	store.now = clock.Now
+
+	// Unique keys, one per second: the expired keys are removed, and the log compacted.
+	for i := range 10 * minCompactRecords {
+		if err := store.Add(fmt.Sprintf("key-%d", i)); err != nil {
+			t.Fatalf("Error adding key: %v", err)
+		}
+		clock.now = clock.now.Add(time.Second)
+	}
+	if store.Len() > minCompactRecords {
+		t.Fatalf("Expired keys should be removed, got %d keys", store.Len())
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Error reading log: %v", err)
+	}
+	if lines := bytes.Count(data, []byte("\n")); lines > minCompactRecords {
+		t.Fatalf("Log should be compacted, got %d records", lines)
+	}
+}
+
+func TestDeduperStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seen.log")
+	store, err := OpenFileSeenStore(path, 0)
+	if err != nil {
+		t.Fatalf("Error opening store: %v", err)
+	}
+	deduper := NewDeduper(WithDedupeStore(store))
+	if deduper.IsDuplicate(&NewsArticle{Id: "1", Link: "https://example.com/1"}) {
+		t.Fatalf("First article should not be a duplicate")
+	}
+	store.Close()
+
+	// After a restart, the articles seen are still duplicates.
+	store, err = OpenFileSeenStore(path, 0)
+	if err != nil {
+		t.Fatalf("Error reopening store: %v", err)
+	}
+	defer store.Close()
+	deduper = NewDeduper(WithDedupeStore(store))
+	if !deduper.IsDuplicate(&NewsArticle{Id: "1"}) || !deduper.IsDuplicate(&NewsArticle{Id: "2", Link: "https://www.example.com/1/"}) {
+		t.Fatalf("Articles seen before the restart should be duplicates")
+	}
+	if deduper.IsDuplicate(&NewsArticle{Id: "3", Link: "https://example.com/3"}) {
+		t.Fatalf("New article should not be a duplicate")
+	}
+	if err := deduper.Err(); err != nil {
+		t.Fatalf("Deduper store error: %v", err)
+	}
+}
@@ -3,67 +3,108 @@ package newsdata
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"iter"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 )
 
 // NewsService handles operations related to news articles from the NewsData API.
 //
-// It provides methods to fetch latest news, news archives, and crypto news.
-type NewsService struct {
+// It provides methods to fetch latest news, news archives, crypto news and market news.
+// P is the type of request parameters accepted by the endpoint, so that a parameter unsupported
+// by the endpoint (e.g. WithCoins on latest news) is rejected at compile time.
+type NewsService[P requestParam] struct {
 	client   *NewsDataClient
 	endpoint endpoint
 }
 
-func (c *NewsDataClient) newLatestNewsService() *NewsService {
-	return &NewsService{
+// LatestNewsService is the service for the latest news endpoint.
+type LatestNewsService = NewsService[LatestNewsParam]
+
+// NewsArchiveService is the service for the news archive endpoint.
+//
+// In addition to the methods of NewsService, it can backfill a date range concurrently (see Backfill).
+type NewsArchiveService struct {
+	NewsService[NewsArchiveParam]
+}
+
+// CryptoNewsService is the service for the crypto news endpoint.
+type CryptoNewsService = NewsService[CryptoNewsParam]
+
+// MarketNewsService is the service for the market news endpoint.
+type MarketNewsService = NewsService[MarketNewsParam]
+
+func (c *NewsDataClient) newLatestNewsService() *LatestNewsService {
+	return &LatestNewsService{
 		client:   c,
 		endpoint: endpointLatestNews,
 	}
 }
 
-func (c *NewsDataClient) newNewsArchiveService() *NewsService {
-	return &NewsService{
-		client:   c,
-		endpoint: endpointNewsArchive,
+func (c *NewsDataClient) newNewsArchiveService() *NewsArchiveService {
+	return &NewsArchiveService{
+		NewsService: NewsService[NewsArchiveParam]{
+			client:   c,
+			endpoint: endpointNewsArchive,
+		},
 	}
 }
 
-func (c *NewsDataClient) newCryptoNewsService() *NewsService {
-	return &NewsService{
+func (c *NewsDataClient) newCryptoNewsService() *CryptoNewsService {
+	return &CryptoNewsService{
 		client:   c,
 		endpoint: endpointCoinNews,
 	}
 }
 
+func (c *NewsDataClient) newMarketNewsService() *MarketNewsService {
+	return &MarketNewsService{
+		client:   c,
+		endpoint: endpointMarketNews,
+	}
+}
+
 // DateTime is a wrapper around time.Time that implements custom JSON unmarshaling
 // to handle the date format used by the NewsData API.
+//
+// It is marshaled in RFC 3339 format with nanoseconds (e.g. "2025-01-09T14:30:00Z"), or null for the zero time,
+// and unmarshaled from either this format or the API format, so that a marshal/unmarshal round trip is lossless.
 type DateTime struct {
 	time.Time
 }
 
 // SentimentStats represents the sentiment analysis statistics for a news article.
 // Each field represents the probability score for that sentiment category.
+//
+// It is marshaled as a JSON object, or null when no statistics are available (zero value).
+// Its text form is "positive=91.27,neutral=8.1,negative=0.63", or empty for the zero value.
 type SentimentStats struct {
 	Positive float64 `json:"positive"`
 	Neutral  float64 `json:"neutral"`
 	Negative float64 `json:"negative"`
 }
 
-// Tags is a wrapper around []string for handling article tags.
-// It implements custom JSON unmarshaling to handle API restriction messages
-// and null values gracefully.
+// Tags is a wrapper around []string for handling article list fields (tags, keywords, creators, coins...).
+// It implements custom JSON unmarshaling to accept both arrays and comma-separated strings,
+// and to handle API restriction messages and null values gracefully.
+//
+// It is marshaled as a JSON array, or null when empty. Its text form is a CSV record
+// (e.g. `technology,"santa clara,california"`), so that tags containing commas round-trip.
 type Tags []string
 
 // NewsArticle represents a single news article from the NewsData API.
 //
 // See https://newsdata.io/documentation/#http_response for more details.
 type NewsArticle struct {
-	Id             string         `json:"Article_id"`      // Unique identifier for the article
+	Id             string         `json:"article_id"`      // Unique identifier for the article
 	Title          string         `json:"title"`           // Article headline
 	Link           string         `json:"link"`            // URL to the original article
-	Keywords       []string       `json:"keywords"`        // Keywords associated with the article
-	Creator        []string       `json:"creator"`         // Authors of the article
+	Keywords       Tags           `json:"keywords"`        // Keywords associated with the article
+	Creator        Tags           `json:"creator"`         // Authors of the article
 	VideoURL       string         `json:"video_url"`       // URL to associated video content
 	Description    string         `json:"description"`     // Brief summary of the article
 	Content        string         `json:"content"`         // Full article content
@@ -82,7 +123,9 @@ type NewsArticle struct {
 	Sentiment      string         `json:"sentiment"`       // Overall sentiment classification
 	SentimentStats SentimentStats `json:"sentiment_stats"` // Detailed sentiment analysis scores
 	AiRegions      Tags           `json:"ai_region"`       // AI-detected geographical regions
-	Coin           []string       `json:"coin"`            // Cryptocurrency coins mentioned
+	Coin           Tags           `json:"coin"`            // Cryptocurrency coins mentioned
+	Symbols        Tags           `json:"symbol"`          // Stock ticker symbols mentioned (market news only)
+	Organizations  Tags           `json:"ai_org"`          // AI-detected organizations (market news only)
 	Duplicate      bool           `json:"duplicate"`       // Whether article is a duplicate
 }
 
@@ -94,10 +137,11 @@ type newsResponse struct {
 	TotalResults int           `json:"totalResults"` // Total number of NewsArticles matching the query
 	Articles     []NewsArticle `json:"results"`      // Array of NewsArticles
 	NextPage     string        `json:"nextPage"`     // Next page token
+	Quota        Quota         `json:"-"`            // Credits snapshot after the response, as returned by NewsDataClient.Quota
 }
 
-func (s *NewsService) fetch(ctx context.Context, params requestParams) (*newsResponse, error) {
-	body, err := s.client.fetch(ctx, s.endpoint, params)
+func (s *NewsService[P]) fetch(ctx context.Context, params requestParams) (*newsResponse, error) {
+	body, quota, err := s.client.fetch(ctx, s.endpoint, params)
 	if err != nil {
 		return nil, fmt.Errorf("fetchNews - error fetching news - error: %w", err)
 	}
@@ -106,51 +150,171 @@ func (s *NewsService) fetch(ctx context.Context, params requestParams) (*newsRes
 	if err := json.Unmarshal(body, &data); err != nil {
 		return nil, fmt.Errorf("fetchNews - error unmarshalling news response - error: %w", err)
 	}
+	if timezone := params["timezone"]; timezone != "" {
+		if err := applyTimezone(data.Articles, timezone); err != nil {
+			s.client.logger.Warn("unknown timezone, publication dates are left in the timezone of the articles", "timezone", timezone, "error", err)
+		}
+	}
+	if s.client.location != nil {
+		for i := range data.Articles {
+			data.Articles[i].NormalizeTimezone(s.client.location)
+		}
+	}
+	data.Quota = quota
 	return &data, nil
 }
 
-// Stream returns a channel that streams news articles matching the given query and parameters.
+// Page is a page of news articles returned by the API.
+type Page struct {
+	Articles     []*NewsArticle // Articles of the page
+	TotalResults int            // Total number of articles matching the query
+	NextPage     string         // Token of the next page, empty if this is the last page
+	Quota        Quota          // Credits snapshot after the response, as returned by NewsDataClient.Quota
+	Cursor       *Cursor        // Cursor to resume the request after this page with WithResumeFrom, nil if this is the last page
+}
+
+// newPage creates a Page from a news API response.
+func newPage(res *newsResponse) *Page {
+	page := &Page{
+		Articles:     make([]*NewsArticle, len(res.Articles)),
+		TotalResults: res.TotalResults,
+		NextPage:     res.NextPage,
+		Quota:        res.Quota,
+	}
+	for i := range res.Articles {
+		page.Articles[i] = &res.Articles[i]
+	}
+	return page
+}
+
+// FetchPage retrieves a single page of news articles matching the given query and parameters.
 //
-// It handles pagination automatically and continues streaming until all matching articles
-// are retrieved or the context is cancelled. Errors are sent on the error channel.
-func (s *NewsService) Stream(ctx context.Context, query string, params ...NewsRequestParams) (<-chan *NewsArticle, <-chan error) {
-	out := make(chan *NewsArticle)
-	errChan := make(chan error, 1)
+// pageToken is the NextPage token of the previous page, or empty for the first page. The returned page
+// gives access to the total number of matching articles, e.g. to decide if a query is too broad
+// before paging through it.
+func (s *NewsService[P]) FetchPage(ctx context.Context, query string, pageToken string, params ...P) (*Page, error) {
+	reqParams, err := newRequestParams(query, s.client.logger, s.client.strictParams, s.endpoint, params...)
+	if err != nil {
+		return nil, fmt.Errorf("newsdata: FetchPage: %w", err)
+	}
+	if pageToken != "" {
+		reqParams["page"] = pageToken
+	}
+	res, err := s.fetch(ctx, reqParams)
+	if err != nil {
+		return nil, fmt.Errorf("newsdata: FetchPage: %w", err)
+	}
+	page := newPage(res)
+	if res.NextPage != "" {
+		page.Cursor = newCursor(s.endpoint, reqParams, res.NextPage, resumedArticlesCount(params)+len(res.Articles))
+	}
+	return page, nil
+}
 
-	go func() {
+// Pages returns an iterator over the pages of news articles matching the given query and parameters.
+//
+// It handles pagination automatically and stops when all matching articles are retrieved, when the
+// loop is exited, or when an error occurs. An error is yielded at most once, as the last value.
+func (s *NewsService[P]) Pages(ctx context.Context, query string, params ...P) iter.Seq2[*Page, error] {
+	return func(yield func(*Page, error) bool) {
+		reqParams, err := newRequestParams(query, s.client.logger, s.client.strictParams, s.endpoint, params...)
+		if err != nil {
+			yield(nil, fmt.Errorf("newsdata: Pages: %w", err))
+			return
+		}
+		s.pages(ctx, reqParams, resumedArticlesCount(params))(yield)
+	}
+}
+
+// pages returns an iterator over the pages of news articles for already built request parameters.
+//
+// articlesCount is the number of articles already retrieved, when resuming from a cursor.
+func (s *NewsService[P]) pages(ctx context.Context, reqParams requestParams, articlesCount int) iter.Seq2[*Page, error] {
+	return func(yield func(*Page, error) bool) {
 		start := time.Now()
-		defer close(out)
-		defer close(errChan)
-		articlesCount := 0
-		reqParams := newRequestParams(query, s.client.logger, s.endpoint, params...)
 		s.client.logger.Debug("retrieving articles started", "service", s.endpoint.String(), "params", reqParams.String())
 		defer func() {
 			// Closure are evaluated when the function is executed, not when defer is defined. Hence, articlesCount & duration will have the correct value.
 			s.client.logger.Debug("retrieving articles ended", "service", s.endpoint.String(), "params", reqParams.String(), "articlesCount", articlesCount, "duration", time.Since(start))
 		}()
 		for {
+			if err := ctx.Err(); err != nil {
+				yield(nil, fmt.Errorf("newsdata: Pages - context done: %w", err))
+				return
+			}
 			res, err := s.fetch(ctx, reqParams)
 			if err != nil {
-				errChan <- fmt.Errorf("newsdata: Stream: %w", err)
+				yield(nil, fmt.Errorf("newsdata: Pages: %w", err))
 				return
 			}
-			for _, article := range res.Articles {
-				select {
-				case out <- &article:
-					articlesCount++
-				case <-ctx.Done():
-					errChan <- fmt.Errorf("newsdata: Stream - context done: %w", ctx.Err())
+			articlesCount += len(res.Articles)
+			page := newPage(res)
+			last := articlesCount >= res.TotalResults || res.NextPage == ""
+			if !last {
+				page.Cursor = newCursor(s.endpoint, reqParams, res.NextPage, articlesCount)
+			}
+			if !yield(page, nil) || last {
+				return
+			}
+			reqParams["page"] = res.NextPage
+		}
+	}
+}
+
+// All returns an iterator over the news articles matching the given query and parameters.
+//
+// It handles pagination automatically and stops when all matching articles are retrieved, when the
+// loop is exited, or when an error occurs. An error is yielded at most once, as the last value.
+//
+//	for article, err := range client.LatestNews.All(ctx, "climate") {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(article.Title)
+//	}
+func (s *NewsService[P]) All(ctx context.Context, query string, params ...P) iter.Seq2[*NewsArticle, error] {
+	return func(yield func(*NewsArticle, error) bool) {
+		for page, err := range s.Pages(ctx, query, params...) {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			for _, article := range page.Articles {
+				if !yield(article, nil) {
 					return
 				}
 			}
-			if articlesCount == res.TotalResults {
+		}
+	}
+}
+
+// Stream returns a channel that streams news articles matching the given query and parameters.
+//
+// It handles pagination automatically and continues streaming until all matching articles
+// are retrieved or the context is cancelled. Errors are sent on the error channel, as a *StreamError
+// carrying the cursor from which the request can be resumed.
+func (s *NewsService[P]) Stream(ctx context.Context, query string, params ...P) (<-chan *NewsArticle, <-chan error) {
+	out := make(chan *NewsArticle)
+	errChan := make(chan error, 1)
+
+	go func() {
+		defer close(out)
+		defer close(errChan)
+		cursor := resumedCursor(params)
+		for page, err := range s.Pages(ctx, query, params...) {
+			if err != nil {
+				errChan <- &StreamError{Err: err, Cursor: cursor}
 				return
 			}
-			if res.NextPage != "" {
-				reqParams["page"] = res.NextPage
-			} else {
-				return
+			for _, article := range page.Articles {
+				select {
+				case out <- article:
+				case <-ctx.Done():
+					errChan <- &StreamError{Err: fmt.Errorf("newsdata: Stream - context done: %w", ctx.Err()), Cursor: cursor}
+					return
+				}
 			}
+			cursor = page.Cursor
 		}
 	}()
 	return out, errChan
@@ -159,31 +323,96 @@ func (s *NewsService) Stream(ctx context.Context, query string, params ...NewsRe
 // Get retrieves a specified number of news articles matching the given query and parameters.
 //
 // It returns at most maxResults articles. If maxResults is 0, it returns all matching articles.
-func (s *NewsService) Get(ctx context.Context, query string, maxResults int, params ...NewsRequestParams) ([]*NewsArticle, error) {
+func (s *NewsService[P]) Get(ctx context.Context, query string, maxResults int, params ...P) ([]*NewsArticle, error) {
 	var articles []*NewsArticle
 	if maxResults > 0 {
 		articles = make([]*NewsArticle, 0, maxResults)
 	} else {
 		articles = make([]*NewsArticle, 0)
 	}
-	// maxResultsCause := errors.New("maxResults reached")
-	newCtx, cancel := context.WithCancel(ctx)
-	defer cancel()
-	articlesChan, errChan := s.Stream(newCtx, query, params...)
-	for article := range articlesChan {
+	for article, err := range s.All(ctx, query, params...) {
+		if err != nil {
+			return nil, err
+		}
 		articles = append(articles, article)
 		if maxResults > 0 && len(articles) == maxResults {
-			cancel()
-			return articles[:maxResults], nil
+			break
 		}
 	}
-	// Check if there was an error in the stream
-	for {
-		select {
-		case err := <-errChan:
-			return nil, err
-		default:
-			return articles, nil
+	return articles, nil
+}
+
+// maxIDsPerRequest is the maximum number of article IDs accepted by the API in a single request.
+const maxIDsPerRequest = 50
+
+// idsConcurrency is the maximum number of concurrent requests made by GetByIDs.
+const idsConcurrency = 4
+
+// GetByIDs retrieves the articles with the given IDs.
+//
+// The IDs are sent in batches of up to 50 IDs (the API maximum), fetched concurrently under the client's
+// rate limiter and retry policy. The articles are returned in the order of ids, with a nil entry for
+// each ID that was not found. In that case, the returned error is a *NotFoundError listing the missing IDs,
+// along with the articles that were found.
+func (s *NewsService[P]) GetByIDs(ctx context.Context, ids ...string) ([]*NewsArticle, error) {
+	uniqueIDs := make([]string, 0, len(ids))
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			uniqueIDs = append(uniqueIDs, id)
 		}
 	}
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		found    = make(map[string]*NewsArticle, len(uniqueIDs))
+		errs     []error
+		inFlight = make(chan struct{}, idsConcurrency)
+	)
+	for batch := range slices.Chunk(uniqueIDs, maxIDsPerRequest) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case inFlight <- struct{}{}:
+				defer func() { <-inFlight }()
+			case <-ctx.Done():
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("newsdata: GetByIDs - context done: %w", ctx.Err()))
+				mu.Unlock()
+				return
+			}
+			reqParams := requestParams{"id": strings.Join(batch, ",")}
+			for page, err := range s.pages(ctx, reqParams, 0) {
+				mu.Lock()
+				if err != nil {
+					errs = append(errs, fmt.Errorf("newsdata: GetByIDs: %w", err))
+				} else {
+					for _, article := range page.Articles {
+						found[article.Id] = article
+					}
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	articles := make([]*NewsArticle, len(ids))
+	var missing []string
+	for i, id := range ids {
+		articles[i] = found[id]
+		if articles[i] == nil && !slices.Contains(missing, id) {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) > 0 {
+		return articles, &NotFoundError{IDs: missing}
+	}
+	return articles, nil
 }
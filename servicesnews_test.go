@@ -0,0 +1,65 @@
+package newsdata_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/sicamois/newsdata"
+	"github.com/sicamois/newsdata/newsdatatest"
+)
+
+func TestGetByIDs(t *testing.T) {
+	server := newsdatatest.NewServer()
+	defer server.Close()
+	server.AddArticles(newsdatatest.EndpointLatest, newsdatatest.SampleArticles(120)...)
+	client := server.NewClient()
+
+	ids := make([]string, 0, 102)
+	for i := 119; i >= 20; i-- {
+		ids = append(ids, fmt.Sprintf("article-%04d", i))
+	}
+	ids = append(ids, "missing", "article-0119")
+	articles, err := client.LatestNews.GetByIDs(context.Background(), ids...)
+	var notFound *newsdata.NotFoundError
+	if !errors.Is(err, newsdata.ErrArticleNotFound) || !errors.As(err, &notFound) || len(notFound.IDs) != 1 || notFound.IDs[0] != "missing" {
+		t.Fatalf("Error should be a NotFoundError for the missing ID: %v", err)
+	}
+	if len(articles) != len(ids) || articles[100] != nil {
+		t.Fatalf("Invalid number of articles: %d - should be %d", len(articles), len(ids))
+	}
+	for i, article := range articles {
+		if i != 100 && article.Id != ids[i] {
+			t.Fatalf("Article %d should be %s, got %s", i, ids[i], article.Id)
+		}
+	}
+	// 101 unique IDs in 3 batches: 2 batches of 50 IDs returned in 5 pages of 10 articles, and the missing ID.
+	if requests := server.Requests(); len(requests) != 11 {
+		t.Fatalf("Invalid number of requests: %d - should be 11", len(requests))
+	}
+}
+
+func TestMarketNews(t *testing.T) {
+	server := newsdatatest.NewServer()
+	defer server.Close()
+	server.AddArticles(newsdatatest.EndpointMarket, newsdatatest.SampleArticles(20)...)
+	client := server.NewClient(newsdata.WithLogWriter(io.Discard))
+
+	articles, err := client.MarketNews.Get(context.Background(), "", 0, newsdata.WithSymbols("aapl", "not a symbol"), newsdata.WithLanguages("en"))
+	if err != nil {
+		t.Fatalf("Error fetching Market News: %v", err)
+	}
+	if len(articles) != 4 {
+		t.Fatalf("Invalid number of articles: %d - should be 4", len(articles))
+	}
+	for _, article := range articles {
+		if article.Symbols[0] != "AAPL" {
+			t.Fatalf("Article does not match the symbol: %v", article.Symbols)
+		}
+	}
+	if query := server.Requests()[0].Query; query.Get("symbol") != "AAPL" {
+		t.Fatalf("Invalid symbol parameter: %s", query.Get("symbol"))
+	}
+}
@@ -51,7 +51,10 @@ type sourcesResponse struct {
 func (s *SourcesService) Get(ctx context.Context, params ...SourceRequestParams) ([]*Source, error) {
 	start := time.Now()
 	sources := make([]*Source, 0, 100)
-	reqParams := newRequestParams("", s.client.logger, endpointSources, params...)
+	reqParams, err := newRequestParams("", s.client.logger, s.client.strictParams, endpointSources, params...)
+	if err != nil {
+		return nil, fmt.Errorf("newsdata: getSources - error validating parameters: %w", err)
+	}
 
 	s.client.logger.Debug("retrieving sources started", "service", endpointSources.String(), "params", reqParams.String())
 	defer func() {
@@ -59,7 +62,7 @@ func (s *SourcesService) Get(ctx context.Context, params ...SourceRequestParams)
 		s.client.logger.Debug("retrieving sources ended", "service", endpointSources.String(), "params", reqParams.String(), "sourcesCount", len(sources), "duration", time.Since(start))
 	}()
 
-	body, err := s.client.fetch(ctx, endpointSources, reqParams)
+	body, _, err := s.client.fetch(ctx, endpointSources, reqParams)
 	if err != nil {
 		return nil, fmt.Errorf("newsdata: getSources - error fetching sources - error: %w", err)
 	}
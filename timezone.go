@@ -0,0 +1,74 @@
+package newsdata
+
+import (
+	"sync"
+	"time"
+)
+
+// locations caches the locations loaded by loadLocation, by name.
+var locations sync.Map
+
+// loadLocation returns the location with the given IANA name (e.g. "Asia/Kolkata"), loading it only once.
+//
+// The timezone database of the system is used. Programs running on systems without it can embed one
+// by importing the time/tzdata package.
+func loadLocation(name string) (*time.Location, error) {
+	if loc, ok := locations.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	locations.Store(name, loc)
+	return loc, nil
+}
+
+// articleLocation returns the location of a PubDateTZ value, or UTC if it is empty or unknown.
+func articleLocation(name string) *time.Location {
+	if name == "" {
+		return time.UTC
+	}
+	loc, err := loadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// applyTimezone sets the timezone of the publication dates of articles to the timezone requested with WithTimezone.
+//
+// The API sends the publication dates in the requested timezone, as wall clock times without offset,
+// so they are reinterpreted in this timezone.
+func applyTimezone(articles []NewsArticle, timezone string) error {
+	loc, err := loadLocation(timezone)
+	if err != nil {
+		return err
+	}
+	for i := range articles {
+		article := &articles[i]
+		if !article.PubDate.IsZero() && article.PubDate.Location() != loc {
+			date := article.PubDate.Time
+			article.PubDate.Time = time.Date(date.Year(), date.Month(), date.Day(), date.Hour(), date.Minute(), date.Second(), date.Nanosecond(), loc)
+		}
+		article.PubDateTZ = timezone
+	}
+	return nil
+}
+
+// NormalizeTimezone converts the publication date of the article to loc, without changing the instant it represents,
+// and sets PubDateTZ to the name of loc.
+func (a *NewsArticle) NormalizeTimezone(loc *time.Location) {
+	if !a.PubDate.IsZero() {
+		a.PubDate.Time = a.PubDate.In(loc)
+	}
+	a.PubDateTZ = loc.String()
+}
+
+// NormalizeTimezone converts the publication dates of articles to loc, e.g. to display articles from
+// sources in different timezones consistently.
+func NormalizeTimezone(articles []*NewsArticle, loc *time.Location) {
+	for _, article := range articles {
+		article.NormalizeTimezone(loc)
+	}
+}
@@ -0,0 +1,60 @@
+package newsdata_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sicamois/newsdata"
+	"github.com/sicamois/newsdata/newsdatatest"
+)
+
+func TestTimezone(t *testing.T) {
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	if err != nil {
+		t.Fatalf("Error loading location: %v", err)
+	}
+	sample := newsdatatest.SampleArticles(2)
+	sample[1].PubDateTZ = "Asia/Kolkata"
+	server := newsdatatest.NewServer()
+	defer server.Close()
+	server.AddArticles(newsdatatest.EndpointLatest, sample...)
+
+	articles, err := server.NewClient().LatestNews.Get(context.Background(), "", 0)
+	if err != nil {
+		t.Fatalf("Error fetching Latest News: %v", err)
+	}
+	for i, article := range articles {
+		if !article.PubDate.Equal(sample[i].PubDate.Time) {
+			t.Fatalf("Publication date should be %v, got %v", sample[i].PubDate, article.PubDate)
+		}
+	}
+	if articles[0].PubDate.Location() != time.UTC || articles[1].PubDate.Location().String() != "Asia/Kolkata" {
+		t.Fatalf("Publication dates should be in the timezone of the articles: %v, %v", articles[0].PubDate, articles[1].PubDate)
+	}
+
+	articles, err = server.NewClient().LatestNews.Get(context.Background(), "", 0, newsdata.WithTimezone("Asia/Tokyo"))
+	if err != nil {
+		t.Fatalf("Error fetching Latest News: %v", err)
+	}
+	for i, article := range articles {
+		if !article.PubDate.Equal(sample[i].PubDate.Time) || article.PubDate.Location().String() != "Asia/Tokyo" || article.PubDateTZ != "Asia/Tokyo" {
+			t.Fatalf("Publication date should be %v in the requested timezone, got %v (%s)", sample[i].PubDate, article.PubDate, article.PubDateTZ)
+		}
+	}
+
+	articles, err = server.NewClient(newsdata.WithLocation(time.UTC)).LatestNews.Get(context.Background(), "", 0, newsdata.WithTimezone("Asia/Tokyo"))
+	if err != nil {
+		t.Fatalf("Error fetching Latest News: %v", err)
+	}
+	for i, article := range articles {
+		if !article.PubDate.Equal(sample[i].PubDate.Time) || article.PubDate.Location() != time.UTC || article.PubDateTZ != "UTC" {
+			t.Fatalf("Publication date should be %v in UTC, got %v (%s)", sample[i].PubDate, article.PubDate, article.PubDateTZ)
+		}
+	}
+
+	newsdata.NormalizeTimezone(articles, kolkata)
+	if !articles[0].PubDate.Equal(sample[0].PubDate.Time) || articles[0].PubDate.Location().String() != "Asia/Kolkata" || articles[0].PubDateTZ != "Asia/Kolkata" {
+		t.Fatalf("Publication date should be %v in Asia/Kolkata, got %v (%s)", sample[0].PubDate, articles[0].PubDate, articles[0].PubDateTZ)
+	}
+}
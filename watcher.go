@@ -0,0 +1,337 @@
+package newsdata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	defaultWatchMinInterval = time.Minute      // Default minimum interval between two polls of a watch
+	defaultWatchMaxInterval = 15 * time.Minute // Default maximum interval between two polls of a watch
+	defaultWatchLookback    = time.Hour        // Default timeframe of the first poll of a watch
+	defaultWatchOverlap     = 10 * time.Minute // Default overlap between the timeframes of two polls, for articles indexed late
+	defaultWatchSeen        = 10000            // Default number of article IDs remembered by each watch, without SeenStore
+	maxWatchTimeframe       = 48 * time.Hour   // Maximum timeframe supported by the API
+)
+
+// WatchEvent is a new article found by a watch, or an error of a poll.
+type WatchEvent struct {
+	Watch   string       // Name of the watch
+	Article *NewsArticle // New article, nil if the poll failed
+	Err     error        // Error of the poll, nil if an article was found
+}
+
+// Watcher polls the latest news for several named watches sharing the same client, and its rate limiter,
+// and emits only the articles not seen yet.
+//
+// Each watch polls with a timeframe covering the time since its previous poll (see WithTimeframe),
+// remembers the IDs of the articles already emitted (see WithSeenStore), and adapts its interval: it polls more often when
+// new articles are found, less often when there are none, when a poll fails or when the credits are running out.
+// Errors are reported as events, and do not stop the watch.
+type Watcher struct {
+	client      *NewsDataClient
+	minInterval time.Duration
+	maxInterval time.Duration
+	lookback    time.Duration
+	overlap     time.Duration
+	seen        SeenStore // Store shared by the watches, nil to use a store per watch
+	handler     func(WatchEvent)
+	events      chan WatchEvent
+
+	mu       sync.Mutex
+	watches  map[string]*watch
+	ctx      context.Context // Context of Run, nil if the watcher is not running
+	stopping bool            // Whether Run is waiting for the watches to stop, so that no watch can be started
+	wg       sync.WaitGroup
+}
+
+// watch is a named query polled by a Watcher.
+type watch struct {
+	name     string
+	query    string
+	params   []LatestNewsParam
+	seen     SeenStore
+	cancel   context.CancelFunc // Stops the polling goroutine, nil if the watch is not running
+	lastPoll time.Time
+}
+
+// WatcherOption is a functional option for configuring a Watcher.
+type WatcherOption func(*Watcher)
+
+// WithPollInterval sets the bounds of the interval between two polls of a watch.
+//
+// If no interval is provided, watches poll between every minute and every 15 minutes.
+func WithPollInterval(minInterval, maxInterval time.Duration) WatcherOption {
+	return func(w *Watcher) {
+		if minInterval > 0 {
+			w.minInterval = minInterval
+		}
+		if maxInterval >= w.minInterval {
+			w.maxInterval = maxInterval
+		}
+	}
+}
+
+// WithLookback sets the timeframe of the first poll of a watch, up to 48 hours.
+//
+// If no lookback is provided, the first poll retrieves the articles of the last hour.
+func WithLookback(lookback time.Duration) WatcherOption {
+	return func(w *Watcher) {
+		if lookback > 0 {
+			w.lookback = min(lookback, maxWatchTimeframe)
+		}
+	}
+}
+
+// WithPollOverlap sets the duration added to the timeframe of each poll, to retrieve the articles
+// indexed by the API after the previous poll but published before it. Articles are only emitted once anyway.
+//
+// If no overlap is provided, 10 minutes are added.
+func WithPollOverlap(overlap time.Duration) WatcherOption {
+	return func(w *Watcher) {
+		if overlap >= 0 {
+			w.overlap = overlap
+		}
+	}
+}
+
+// WithSeenStore sets the store remembering the articles emitted by the watches, e.g. a FileSeenStore
+// so that articles are not emitted again after a restart. Keys are the watch name and the article ID,
+// separated by a slash.
+//
+// An article is recorded once it is received from the Events channel, or once the handler returns, so an article
+// not consumed before the watcher stops is emitted again after a restart.
+//
+// If no store is provided, each watch remembers the last 10000 articles in memory.
+func WithSeenStore(store SeenStore) WatcherOption {
+	return func(w *Watcher) {
+		w.seen = store
+	}
+}
+
+// WithWatchHandler calls handler for each event, instead of sending the events on the Events channel.
+//
+// handler is called from the goroutines of the watches, and must be safe for concurrent use.
+func WithWatchHandler(handler func(WatchEvent)) WatcherOption {
+	return func(w *Watcher) {
+		w.handler = handler
+	}
+}
+
+// NewWatcher creates a new Watcher using the client.
+func (c *NewsDataClient) NewWatcher(opts ...WatcherOption) *Watcher {
+	w := &Watcher{
+		client:      c,
+		minInterval: defaultWatchMinInterval,
+		maxInterval: defaultWatchMaxInterval,
+		lookback:    defaultWatchLookback,
+		overlap:     defaultWatchOverlap,
+		watches:     make(map[string]*watch),
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	if w.handler == nil {
+		w.events = make(chan WatchEvent)
+	}
+	return w
+}
+
+// Events returns the channel on which the events are sent, nil if a handler is set with WithWatchHandler.
+//
+// The channel is unbuffered, and closed when Run returns. Events must be consumed, or the watches are blocked.
+func (w *Watcher) Events() <-chan WatchEvent {
+	return w.events
+}
+
+// Add adds a watch polling the latest news matching the query and parameters.
+//
+// The watch starts immediately if the watcher is running. Add fails if a watch with the same name exists.
+func (w *Watcher) Add(name string, query string, params ...LatestNewsParam) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, ok := w.watches[name]; ok {
+		return fmt.Errorf("newsdata: watch %s already exists", name)
+	}
+	wt := &watch{
+		name:   name,
+		query:  query,
+		params: params,
+		seen:   w.seen,
+	}
+	if wt.seen == nil {
+		wt.seen = NewMemorySeenStore(defaultWatchSeen, 0)
+	}
+	w.watches[name] = wt
+	if w.ctx != nil && !w.stopping {
+		w.start(wt)
+	}
+	return nil
+}
+
+// Remove stops and removes a watch. It does nothing if the watch does not exist.
+func (w *Watcher) Remove(name string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if wt, ok := w.watches[name]; ok {
+		if wt.cancel != nil {
+			wt.cancel()
+		}
+		delete(w.watches, name)
+	}
+}
+
+// Watches returns the names of the watches, sorted.
+func (w *Watcher) Watches() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	names := make([]string, 0, len(w.watches))
+	for name := range w.watches {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Run polls all the watches until the context is cancelled, then closes the Events channel.
+//
+// Run can only be called once. It returns the error of the context.
+func (w *Watcher) Run(ctx context.Context) error {
+	w.mu.Lock()
+	if w.ctx != nil {
+		w.mu.Unlock()
+		return errors.New("newsdata: watcher is already running")
+	}
+	w.ctx = ctx
+	for _, wt := range w.watches {
+		w.start(wt)
+	}
+	w.mu.Unlock()
+
+	<-ctx.Done()
+	w.mu.Lock()
+	w.stopping = true
+	w.mu.Unlock()
+	w.wg.Wait()
+	if w.events != nil {
+		close(w.events)
+	}
+	return ctx.Err()
+}
+
+// start starts the polling goroutine of a watch. It must be called with mu held, once the watcher is running.
+func (w *Watcher) start(wt *watch) {
+	ctx, cancel := context.WithCancel(w.ctx)
+	wt.cancel = cancel
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		w.run(ctx, wt)
+	}()
+}
+
+// run polls a watch until the context is cancelled.
+func (w *Watcher) run(ctx context.Context, wt *watch) {
+	interval := w.minInterval
+	for {
+		found, err := w.poll(ctx, wt)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			w.client.logger.Warn("watch poll failed", "watch", wt.name, "error", err)
+			if !w.emit(ctx, WatchEvent{Watch: wt.name, Err: err}) {
+				return
+			}
+		}
+		interval = w.nextInterval(interval, found, err)
+		w.client.logger.Debug("watch polled", "watch", wt.name, "newArticles", found, "nextPoll", interval)
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// poll retrieves the articles published since the previous poll and emits the new ones, oldest first.
+// It returns the number of new articles.
+func (w *Watcher) poll(ctx context.Context, wt *watch) (int, error) {
+	now := time.Now()
+	timeframe := w.lookback
+	if !wt.lastPoll.IsZero() {
+		timeframe = min(now.Sub(wt.lastPoll)+w.overlap, maxWatchTimeframe)
+	}
+	minutes := max(int((timeframe+time.Minute-1)/time.Minute), 1)
+	params := append(slices.Clone(wt.params), WithTimeframe(0, minutes))
+
+	var articles []*NewsArticle
+	for article, err := range w.client.LatestNews.All(ctx, wt.query, params...) {
+		if err != nil {
+			return 0, err
+		}
+		articles = append(articles, article)
+	}
+	wt.lastPoll = now
+	found := 0
+	for _, article := range slices.Backward(articles) {
+		key := wt.name + "/" + article.Id
+		seen, err := wt.seen.Has(key)
+		if err != nil {
+			return found, fmt.Errorf("newsdata: watch %s - error reading seen store: %w", wt.name, err)
+		}
+		if seen {
+			continue
+		}
+		if !w.emit(ctx, WatchEvent{Watch: wt.name, Article: article}) {
+			return found, ctx.Err()
+		}
+		// Recorded only once received, so that an article is not lost if the watcher stops before it is consumed.
+		if err := wt.seen.Add(key); err != nil {
+			return found, fmt.Errorf("newsdata: watch %s - error writing seen store: %w", wt.name, err)
+		}
+		found++
+	}
+	return found, nil
+}
+
+// emit sends an event to the handler or the Events channel. It returns false if the context is done.
+func (w *Watcher) emit(ctx context.Context, event WatchEvent) bool {
+	if w.handler != nil {
+		w.handler(event)
+		return true
+	}
+	select {
+	case w.events <- event:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// nextInterval returns the interval before the next poll: halved when new articles were found,
+// doubled when none were or when the poll failed, and the maximum when less than 10% of the credits remain.
+func (w *Watcher) nextInterval(interval time.Duration, found int, err error) time.Duration {
+	switch {
+	case err != nil || found == 0:
+		interval *= 2
+	default:
+		interval /= 2
+	}
+	interval = min(max(interval, w.minInterval), w.maxInterval)
+
+	quota := w.client.Quota()
+	if quota.IsKnown() {
+		if quota.Remaining == 0 && time.Until(quota.Reset) > interval {
+			return time.Until(quota.Reset)
+		}
+		if quota.Limit > 0 && quota.Remaining*10 < quota.Limit {
+			return w.maxInterval
+		}
+	}
+	return interval
+}
@@ -0,0 +1,156 @@
+package newsdata_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/sicamois/newsdata"
+	"github.com/sicamois/newsdata/newsdatatest"
+)
+
+func TestWatcher(t *testing.T) {
+	server := newsdatatest.NewServer()
+	defer server.Close()
+	now := time.Date(2025, 1, 1, 0, 30, 0, 0, time.UTC)
+	server.SetNow(func() time.Time { return now })
+	// 3 articles published at 00:00, 23:00 and 22:00.
+	server.AddArticles(newsdatatest.EndpointLatest, newsdatatest.SampleArticles(3)...)
+	server.InjectFault(newsdatatest.EndpointLatest, newsdatatest.Fault{StatusCode: 500, Code: "ServerError", Message: "Internal error", Times: 10})
+	client := server.NewClient(newsdata.WithLogWriter(io.Discard), newsdata.WithRetryPolicy(newsdata.RetryPolicy{MaxAttempts: 1}))
+
+	watcher := client.NewWatcher(newsdata.WithPollInterval(time.Millisecond, 5*time.Millisecond), newsdata.WithLookback(2*time.Hour))
+	if err := watcher.Add("all", ""); err != nil {
+		t.Fatalf("Error adding watch: %v", err)
+	}
+	if err := watcher.Add("all", ""); err == nil {
+		t.Fatalf("Adding a watch with the same name should fail")
+	}
+	if err := watcher.Add("us", "", newsdata.WithCountries("us")); err != nil {
+		t.Fatalf("Error adding watch: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	done := make(chan error)
+	go func() { done <- watcher.Run(ctx) }()
+
+	articles := map[string][]string{}
+	errorsCount := 0
+	next := func() newsdata.WatchEvent {
+		for event := range watcher.Events() {
+			if event.Err != nil {
+				errorsCount++
+				continue
+			}
+			articles[event.Watch] = append(articles[event.Watch], event.Article.Id)
+			return event
+		}
+		t.Fatalf("Watcher stopped before the expected events")
+		return newsdata.WatchEvent{}
+	}
+	// The first polls fail, then the watches recover: "all" emits the 2 articles published within the last 2 hours,
+	// oldest first, and "us" the only US one.
+	for range 3 {
+		next()
+	}
+	if errorsCount != 10 {
+		t.Fatalf("Errors should be reported as events: %d - should be 10", errorsCount)
+	}
+	if fmt.Sprint(articles["all"]) != "[article-0001 article-0000]" || fmt.Sprint(articles["us"]) != "[article-0000]" {
+		t.Fatalf("Invalid articles emitted: %v", articles)
+	}
+
+	// New articles are emitted once, by the matching watches only.
+	watcher.Remove("us")
+	fresh := newsdatatest.SampleArticles(1)[0]
+	fresh.Id = "fresh"
+	fresh.Countries = []string{"fr"}
+	fresh.PubDate = newsdata.DateTime{Time: now.Add(-time.Minute)}
+	server.AddArticles(newsdatatest.EndpointLatest, fresh)
+	if event := next(); event.Watch != "all" || event.Article.Id != "fresh" {
+		t.Fatalf("Invalid event: %s %s", event.Watch, event.Article.Id)
+	}
+
+	cancel()
+	for event := range watcher.Events() {
+		if event.Article != nil {
+			t.Fatalf("Article emitted twice: %s", event.Article.Id)
+		}
+	}
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run should return the context error: %v", err)
+	}
+}
+
+func TestWatcherSeenOnReceive(t *testing.T) {
+	server := newsdatatest.NewServer()
+	defer server.Close()
+	server.SetNow(func() time.Time { return time.Date(2025, 1, 1, 0, 30, 0, 0, time.UTC) })
+	// 2 articles published at 00:00 and 23:00.
+	server.AddArticles(newsdatatest.EndpointLatest, newsdatatest.SampleArticles(2)...)
+	client := server.NewClient()
+
+	store := newsdata.NewMemorySeenStore(10, 0)
+	emitting := make(chan struct{})
+	notifying := notifyingStore{SeenStore: store, onHas: func(key string) {
+		if key == "all/article-0000" {
+			close(emitting)
+		}
+	}}
+	watcher := client.NewWatcher(newsdata.WithLookback(2*time.Hour), newsdata.WithSeenStore(notifying))
+	watcher.Add("all", "")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	done := make(chan error)
+	go func() { done <- watcher.Run(ctx) }()
+
+	// The second article is being emitted when the watcher stops: it must not be recorded as seen.
+	if event := <-watcher.Events(); event.Article == nil || event.Article.Id != "article-0001" {
+		t.Fatalf("Invalid event: %+v", event)
+	}
+	<-emitting
+	cancel()
+	<-done
+	if seen, _ := store.Has("all/article-0001"); !seen {
+		t.Fatalf("Received article should be recorded as seen")
+	}
+	if seen, _ := store.Has("all/article-0000"); seen {
+		t.Fatalf("Article not received should not be recorded as seen")
+	}
+}
+
+func TestWatcherAddWhileStopping(t *testing.T) {
+	server := newsdatatest.NewServer()
+	defer server.Close()
+	client := server.NewClient()
+
+	// Watches added while Run stops must not be started once Run waits for the watches.
+	for range 20 {
+		watcher := client.NewWatcher(newsdata.WithPollInterval(time.Millisecond, time.Millisecond))
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() { done <- watcher.Run(ctx) }()
+		go cancel()
+		for i := range 20 {
+			watcher.Add(fmt.Sprint(i), "")
+		}
+		for range watcher.Events() {
+		}
+		<-done
+	}
+}
+
+// notifyingStore is a SeenStore calling onHas before each lookup.
+type notifyingStore struct {
+	newsdata.SeenStore
+	onHas func(key string)
+}
+
+func (s notifyingStore) Has(key string) (bool, error) {
+	s.onHas(key)
+	return s.SeenStore.Has(key)
+}